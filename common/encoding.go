@@ -0,0 +1,36 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"math/big"
+)
+
+// EncodeEdwardsPoint returns the 32 byte RFC 8032 encoding of a point on edwards25519: y little endian with the sign of
+// x in the top bit. It is the Ed25519 public key of the point.
+func EncodeEdwardsPoint(x, y *big.Int) []byte {
+	enc := IntToLittleEndian(y, 32)
+	enc[31] |= byte(x.Bit(0)) << 7
+	return enc
+}
+
+// IntToLittleEndian returns the `size` byte little endian encoding of the non-negative `v`, which must fit in it
+func IntToLittleEndian(v *big.Int, size int) []byte {
+	return reverseBytes(v.FillBytes(make([]byte, size)))
+}
+
+// LittleEndianToInt reads `le` as a little endian unsigned integer
+func LittleEndianToInt(le []byte) *big.Int {
+	return new(big.Int).SetBytes(reverseBytes(append([]byte(nil), le...)))
+}
+
+func reverseBytes(bz []byte) []byte {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+	return bz
+}
@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// ECPublicKey is a public key on an elliptic curve, such as the *crypto.ECPoint of the ECDSAPub or EDDSAPub saved by
+// keygen. The key's curve selects the signature scheme.
+type ECPublicKey interface {
+	Curve() elliptic.Curve
+	X() *big.Int
+	Y() *big.Int
+}
+
+// Verify checks the signature of the digest or message in x.M under `pub`. See VerifyBytes.
+func (x *ECSignature) Verify(pub ECPublicKey) error {
+	if x == nil || len(x.M) == 0 {
+		return errors.New("the signature has no message M to verify")
+	}
+	return x.VerifyBytes(pub, x.M)
+}
+
+// VerifyBytes checks the signature of `msg` under `pub`. On secp256k1 and P-256, `msg` is the digest that was signed
+// with ECDSA, and S must be in the lower half of the curve order. On ed25519, `msg` is the message that was signed
+// with EdDSA as in RFC 8032. R and S must be minimally encoded, and Signature must be their canonical encoding.
+// When x.M is set, it must equal `msg`.
+func (x *ECSignature) VerifyBytes(pub ECPublicKey, msg []byte) error {
+	if x == nil {
+		return errors.New("the signature is nil")
+	}
+	if pub == nil || pub.Curve() == nil || pub.X() == nil || pub.Y() == nil {
+		return errors.New("the public key is nil")
+	}
+	if len(x.M) != 0 && !bytes.Equal(x.M, msg) {
+		return errors.New("the signature was made for a different message M")
+	}
+	switch curve := pub.Curve().(type) {
+	case *btcec.KoblitzCurve:
+		if curve.Params().Name != btcec.S256().Params().Name {
+			return fmt.Errorf("unsupported Koblitz curve %s", curve.Params().Name)
+		}
+		return x.verifyECDSA(pub, msg)
+	case *edwards.TwistedEdwardsCurve:
+		return x.verifyEdDSA(pub, msg)
+	default:
+		if curve != elliptic.P256() {
+			return fmt.Errorf("unsupported curve %s", curve.Params().Name)
+		}
+		return x.verifyECDSA(pub, msg)
+	}
+}
+
+func (x *ECSignature) verifyECDSA(pub ECPublicKey, digest []byte) error {
+	N := pub.Curve().Params().N
+	r, err := canonicalScalar("R", x.R, N)
+	if err != nil {
+		return err
+	}
+	s, err := canonicalScalar("S", x.S, N)
+	if err != nil {
+		return err
+	}
+	if s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+		return errors.New("S is not in the lower half of the curve order")
+	}
+	if len(x.Signature) != 0 && !bytes.Equal(x.Signature, append(r.Bytes(), s.Bytes()...)) {
+		return errors.New("Signature is not the encoding of R and S")
+	}
+	if len(x.SignatureRecovery) > 1 || (len(x.SignatureRecovery) == 1 && x.SignatureRecovery[0] > 3) {
+		return errors.New("SignatureRecovery is not a recovery id")
+	}
+	if len(digest) == 0 {
+		return errors.New("the digest is empty")
+	}
+	if !pub.Curve().IsOnCurve(pub.X(), pub.Y()) {
+		return errors.New("the public key is not on the curve")
+	}
+	pk := &ecdsa.PublicKey{Curve: pub.Curve(), X: pub.X(), Y: pub.Y()}
+	if !ecdsa.Verify(pk, digest, r, s) {
+		return errors.New("ECDSA signature verification failed")
+	}
+	return nil
+}
+
+func (x *ECSignature) verifyEdDSA(pub ECPublicKey, msg []byte) error {
+	if len(x.Signature) != ed25519.SignatureSize {
+		return fmt.Errorf("an EdDSA Signature must be %d bytes, got %d", ed25519.SignatureSize, len(x.Signature))
+	}
+	// Signature is R || S with both little endian; the R and S fields hold the same values big endian
+	encR, encS := x.Signature[:32], x.Signature[32:]
+	if len(x.R) != 0 && !bytes.Equal(x.R, LittleEndianToInt(encR).Bytes()) {
+		return errors.New("R is not the encoding in Signature")
+	}
+	s := LittleEndianToInt(encS)
+	if s.Cmp(pub.Curve().Params().N) >= 0 {
+		return errors.New("S is not reduced modulo the group order")
+	}
+	if len(x.S) != 0 && !bytes.Equal(x.S, s.Bytes()) {
+		return errors.New("S is not the encoding in Signature")
+	}
+	if !pub.Curve().IsOnCurve(pub.X(), pub.Y()) {
+		return errors.New("the public key is not on the curve")
+	}
+	if !ed25519.Verify(EncodeEdwardsPoint(pub.X(), pub.Y()), msg, x.Signature) {
+		return errors.New("EdDSA signature verification failed")
+	}
+	return nil
+}
+
+// canonicalScalar parses a minimally encoded big endian integer in [1, N-1]
+func canonicalScalar(name string, bz []byte, N *big.Int) (*big.Int, error) {
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("%s is empty", name)
+	}
+	if bz[0] == 0 {
+		return nil, fmt.Errorf("%s has a leading zero byte", name)
+	}
+	v := new(big.Int).SetBytes(bz)
+	if v.Cmp(N) >= 0 {
+		return nil, fmt.Errorf("%s is not less than the curve order", name)
+	}
+	return v, nil
+}
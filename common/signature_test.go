@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func TestECSignatureVerifyECDSA(t *testing.T) {
+	digest := sha256.Sum256([]byte("verify"))
+	for _, curve := range []elliptic.Curve{tss.EC(tss.EcdsaScheme), tss.EC(tss.EcdsaP256Scheme)} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		assert.NoError(t, err)
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		assert.NoError(t, err)
+		N := curve.Params().N
+		if s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+			s.Sub(N, s)
+		}
+		pub, err := crypto.NewECPoint(curve, key.X, key.Y)
+		assert.NoError(t, err)
+		sig := &ECSignature{R: r.Bytes(), S: s.Bytes(), Signature: append(r.Bytes(), s.Bytes()...), M: digest[:]}
+		assert.NoError(t, sig.Verify(pub), curve.Params().Name)
+		assert.NoError(t, sig.VerifyBytes(pub, digest[:]))
+
+		other := sha256.Sum256([]byte("other"))
+		assert.Error(t, sig.VerifyBytes(pub, other[:]), "M must match the message")
+		sig.M = nil
+		assert.Error(t, sig.VerifyBytes(pub, other[:]), "the signature of another digest must fail")
+
+		highS := new(big.Int).Sub(N, s)
+		high := &ECSignature{R: r.Bytes(), S: highS.Bytes(), M: digest[:]}
+		assert.Error(t, high.Verify(pub), "a high S must be refused")
+
+		padded := &ECSignature{R: append([]byte{0}, r.Bytes()...), S: s.Bytes(), M: digest[:]}
+		assert.Error(t, padded.Verify(pub), "a padded R must be refused")
+
+		otherKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		assert.NoError(t, err)
+		otherPub, err := crypto.NewECPoint(curve, otherKey.X, otherKey.Y)
+		assert.NoError(t, err)
+		sig.M = digest[:]
+		assert.Error(t, sig.Verify(otherPub), "another key must fail")
+	}
+}
+
+func TestECSignatureVerifyEdDSA(t *testing.T) {
+	pubBz, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	msg := []byte{0x00, 0x01, 0x02}
+	sigBz := ed25519.Sign(key, msg)
+
+	edPub, err := edwards.ParsePubKey(pubBz)
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(tss.EC(tss.EddsaScheme), edPub.X, edPub.Y)
+	assert.NoError(t, err)
+
+	sig := &ECSignature{Signature: sigBz, M: msg}
+	assert.NoError(t, sig.Verify(pub))
+	assert.Error(t, sig.VerifyBytes(pub, []byte{0x01, 0x02}), "leading zero bytes are part of the message")
+
+	// S + L verifies with a lax verifier but is not canonical
+	L := tss.EC(tss.EddsaScheme).Params().N
+	le := make([]byte, 32)
+	copy(le, sigBz[32:])
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	sPlusL := new(big.Int).Add(new(big.Int).SetBytes(le), L)
+	be := sPlusL.FillBytes(make([]byte, 32))
+	malleated := append([]byte(nil), sigBz[:32]...)
+	for i := 31; i >= 0; i-- {
+		malleated = append(malleated, be[i])
+	}
+	assert.Error(t, (&ECSignature{Signature: malleated, M: msg}).Verify(pub))
+}
@@ -66,6 +66,10 @@ func (p *ECPoint) Y() *big.Int {
 	return new(big.Int).Set(p.coords[1])
 }
 
+func (p *ECPoint) Curve() elliptic.Curve {
+	return p.curve
+}
+
 func (p *ECPoint) Add(b *ECPoint) (*ECPoint, error) {
 	x, y := p.curve.Add(p.X(), p.Y(), b.X(), b.Y())
 	return NewECPoint(p.curve, x, y)
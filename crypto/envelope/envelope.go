@@ -0,0 +1,234 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package envelope implements a versioned, encrypted at-rest format for secret key material such as keygen save data.
+//
+// The plaintext is sealed with AES-256-GCM. The key is either supplied directly (32 bytes) or derived from a
+// passphrase with scrypt. Every header field is bound to the ciphertext as GCM additional data, so altering the
+// version, content type, KDF parameters, nonce or ciphertext makes Open fail.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Version1 is the only envelope version understood by this package
+	Version1 = 1
+
+	KDFNone   = "none"
+	KDFScrypt = "scrypt"
+
+	CipherAES256GCM = "aes-256-gcm"
+
+	KeyLen  = 32
+	SaltLen = 32
+
+	// upper bounds on the scrypt cost accepted from a file, so a crafted header cannot exhaust memory or time.
+	// scrypt allocates 128*N*r bytes for its table and 128*r*p bytes for its blocks.
+	maxScryptN   = 1 << 22
+	maxScryptRP  = 1 << 8
+	maxScryptP   = 1 << 4
+	maxScryptMem = 1 << 30
+)
+
+var (
+	ErrUnknownVersion   = errors.New("envelope: unknown version")
+	ErrWrongContentType = errors.New("envelope: unexpected content type")
+	ErrWrongSecretKind  = errors.New("envelope: the secret does not match the envelope's key derivation")
+	ErrDecryptionFailed = errors.New("envelope: decryption failed; the secret is wrong or the data was tampered with")
+	ErrMalformed        = errors.New("envelope: malformed envelope")
+
+	// DefaultScryptParams are used when sealing with a passphrase. They cost about 128 MiB of memory per derivation.
+	DefaultScryptParams = ScryptParams{N: 1 << 17, R: 8, P: 1}
+)
+
+type (
+	ScryptParams struct {
+		N    int    `json:"n"`
+		R    int    `json:"r"`
+		P    int    `json:"p"`
+		Salt []byte `json:"salt,omitempty"`
+	}
+
+	// Secret is the passphrase or raw key used to seal or open an envelope.
+	Secret struct {
+		kdf      string
+		material []byte
+		scrypt   ScryptParams
+	}
+
+	header struct {
+		Version     int           `json:"version"`
+		ContentType string        `json:"type"`
+		KDF         string        `json:"kdf"`
+		KDFParams   *ScryptParams `json:"kdf_params,omitempty"`
+		Cipher      string        `json:"cipher"`
+		Nonce       []byte        `json:"nonce"`
+	}
+
+	envelope struct {
+		header
+		Ciphertext []byte `json:"ciphertext"`
+	}
+)
+
+// Passphrase returns a Secret whose encryption key is derived from `passphrase` with scrypt.
+func Passphrase(passphrase []byte) Secret {
+	return Secret{kdf: KDFScrypt, material: passphrase, scrypt: DefaultScryptParams}
+}
+
+// PassphraseWithParams is like Passphrase but with custom scrypt cost parameters. Any salt in `params` is ignored.
+func PassphraseWithParams(passphrase []byte, params ScryptParams) Secret {
+	params.Salt = nil
+	return Secret{kdf: KDFScrypt, material: passphrase, scrypt: params}
+}
+
+// Key returns a Secret that uses `key` directly as the AES-256 key. It must be 32 bytes of uniform randomness.
+func Key(key []byte) Secret {
+	return Secret{kdf: KDFNone, material: key}
+}
+
+// Seal encrypts `plaintext` under `secret` and returns the JSON-encoded envelope.
+// `contentType` names what is inside the envelope and must be given again to Open.
+func Seal(contentType string, plaintext []byte, secret Secret) ([]byte, error) {
+	hdr := header{
+		Version:     Version1,
+		ContentType: contentType,
+		KDF:         secret.kdf,
+		Cipher:      CipherAES256GCM,
+	}
+	if secret.kdf == KDFScrypt {
+		params := secret.scrypt
+		params.Salt = make([]byte, SaltLen)
+		if _, err := rand.Read(params.Salt); err != nil {
+			return nil, err
+		}
+		hdr.KDFParams = &params
+	}
+	if err := hdr.validateKDFParams(); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(hdr, secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	hdr.Nonce = make([]byte, aead.NonceSize())
+	if _, err = rand.Read(hdr.Nonce); err != nil {
+		return nil, err
+	}
+	ad, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&envelope{
+		header:     hdr,
+		Ciphertext: aead.Seal(nil, hdr.Nonce, plaintext, ad),
+	})
+}
+
+// Open checks and decrypts an envelope produced by Seal and returns the plaintext.
+// It refuses envelopes with an unknown version, a different content type, or data that fails authentication.
+func Open(contentType string, sealed []byte, secret Secret) ([]byte, error) {
+	env := new(envelope)
+	if err := json.Unmarshal(sealed, env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if env.Version != Version1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, env.Version)
+	}
+	if env.ContentType != contentType {
+		return nil, fmt.Errorf("%w: got %q, want %q", ErrWrongContentType, env.ContentType, contentType)
+	}
+	if env.Cipher != CipherAES256GCM {
+		return nil, fmt.Errorf("%w: unsupported cipher %q", ErrMalformed, env.Cipher)
+	}
+	if env.KDF != secret.kdf {
+		return nil, ErrWrongSecretKind
+	}
+	if err := env.header.validateKDFParams(); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(env.header, secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: bad nonce length", ErrMalformed)
+	}
+	ad, err := json.Marshal(env.header)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, ad)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+// ----- //
+
+func (hdr header) validateKDFParams() error {
+	switch hdr.KDF {
+	case KDFNone:
+		if hdr.KDFParams != nil {
+			return fmt.Errorf("%w: unexpected kdf_params", ErrMalformed)
+		}
+	case KDFScrypt:
+		p := hdr.KDFParams
+		if p == nil || len(p.Salt) != SaltLen ||
+			p.N < 2 || p.N > maxScryptN || p.N&(p.N-1) != 0 ||
+			p.R < 1 || p.P < 1 || p.P > maxScryptP || p.R*p.P > maxScryptRP ||
+			128*uint64(p.N)*uint64(p.R) > maxScryptMem {
+			return fmt.Errorf("%w: bad scrypt parameters", ErrMalformed)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported kdf %q", ErrMalformed, hdr.KDF)
+	}
+	return nil
+}
+
+func deriveKey(hdr header, secret Secret) ([]byte, error) {
+	switch hdr.KDF {
+	case KDFNone:
+		if len(secret.material) != KeyLen {
+			return nil, fmt.Errorf("envelope: the key must be %d bytes", KeyLen)
+		}
+		return secret.material, nil
+	case KDFScrypt:
+		if len(secret.material) == 0 {
+			return nil, errors.New("envelope: the passphrase must not be empty")
+		}
+		p := hdr.KDFParams
+		return scrypt.Key(secret.material, p.Salt, p.N, p.R, p.P, KeyLen)
+	default:
+		return nil, errors.New("envelope: a secret must be created with Passphrase() or Key()")
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
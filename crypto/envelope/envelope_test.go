@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package envelope
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testContentType = "test-content"
+
+var (
+	testScryptParams = ScryptParams{N: 1 << 10, R: 8, P: 1}
+	testPlaintext    = []byte(`{"Xi":12345}`)
+)
+
+func TestSealOpenPassphrase(t *testing.T) {
+	secret := PassphraseWithParams([]byte("correct horse"), testScryptParams)
+	sealed, err := Seal(testContentType, testPlaintext, secret)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(testPlaintext))
+
+	plaintext, err := Open(testContentType, sealed, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, testPlaintext, plaintext)
+
+	// the cost parameters come from the envelope, not the secret
+	plaintext, err = Open(testContentType, sealed, Passphrase([]byte("correct horse")))
+	assert.NoError(t, err)
+	assert.Equal(t, testPlaintext, plaintext)
+
+	_, err = Open(testContentType, sealed, PassphraseWithParams([]byte("battery staple"), testScryptParams))
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+}
+
+func TestSealOpenKey(t *testing.T) {
+	key := make([]byte, KeyLen)
+	_, _ = rand.Read(key)
+	sealed, err := Seal(testContentType, testPlaintext, Key(key))
+	assert.NoError(t, err)
+
+	plaintext, err := Open(testContentType, sealed, Key(key))
+	assert.NoError(t, err)
+	assert.Equal(t, testPlaintext, plaintext)
+
+	_, err = Open(testContentType, sealed, Passphrase(key))
+	assert.True(t, errors.Is(err, ErrWrongSecretKind))
+	_, err = Seal(testContentType, testPlaintext, Key(key[1:]))
+	assert.Error(t, err)
+}
+
+func TestOpenRefusesTampering(t *testing.T) {
+	secret := PassphraseWithParams([]byte("correct horse"), testScryptParams)
+	sealed, err := Seal(testContentType, testPlaintext, secret)
+	assert.NoError(t, err)
+
+	tamper := func(f func(env *envelope)) []byte {
+		env := new(envelope)
+		assert.NoError(t, json.Unmarshal(sealed, env))
+		f(env)
+		bz, err := json.Marshal(env)
+		assert.NoError(t, err)
+		return bz
+	}
+
+	_, err = Open(testContentType, tamper(func(env *envelope) { env.Version = 2 }), secret)
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+
+	_, err = Open("other-content", sealed, secret)
+	assert.True(t, errors.Is(err, ErrWrongContentType))
+
+	_, err = Open(testContentType, tamper(func(env *envelope) { env.Ciphertext[0] ^= 1 }), secret)
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+
+	_, err = Open(testContentType, tamper(func(env *envelope) { env.Nonce[0] ^= 1 }), secret)
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+
+	_, err = Open(testContentType, tamper(func(env *envelope) { env.KDFParams.Salt[0] ^= 1 }), secret)
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+
+	_, err = Open(testContentType, tamper(func(env *envelope) { env.KDFParams.N = 1 << 30 }), secret)
+	assert.True(t, errors.Is(err, ErrMalformed))
+
+	_, err = Open(testContentType, []byte("not json"), secret)
+	assert.True(t, errors.Is(err, ErrMalformed))
+}
+
+func TestOpenRefusesExpensiveScrypt(t *testing.T) {
+	secret := PassphraseWithParams([]byte("correct horse"), testScryptParams)
+	sealed, err := Seal(testContentType, testPlaintext, secret)
+	assert.NoError(t, err)
+
+	// each of these is within the bounds on N and r*p alone but would run scrypt with GiBs of memory or many passes;
+	// ErrMalformed shows that the header was refused before scrypt ran, as a failed derivation is not ErrMalformed
+	for _, params := range []ScryptParams{
+		{N: 1 << 22, R: 8, P: 1},   // 4 GiB
+		{N: 1 << 20, R: 16, P: 1},  // 2 GiB
+		{N: 1 << 16, R: 256, P: 1}, // 2 GiB
+		{N: 1 << 10, R: 1, P: 1 << 8},
+	} {
+		env := new(envelope)
+		assert.NoError(t, json.Unmarshal(sealed, env))
+		env.KDFParams.N, env.KDFParams.R, env.KDFParams.P = params.N, params.R, params.P
+		bz, err := json.Marshal(env)
+		assert.NoError(t, err)
+		_, err = Open(testContentType, bz, secret)
+		assert.True(t, errors.Is(err, ErrMalformed), "N=%d r=%d p=%d must be refused", params.N, params.R, params.P)
+
+		_, err = Seal(testContentType, testPlaintext, PassphraseWithParams([]byte("correct horse"), params))
+		assert.True(t, errors.Is(err, ErrMalformed), "N=%d r=%d p=%d must not be sealed", params.N, params.R, params.P)
+	}
+
+	// the default parameters and the memory bound itself are accepted
+	salt := make([]byte, SaltLen)
+	for _, params := range []ScryptParams{DefaultScryptParams, {N: 1 << 20, R: 8, P: 1}, {N: 1 << 10, R: 8, P: 16}} {
+		params.Salt = salt
+		hdr := header{KDF: KDFScrypt, KDFParams: &params}
+		assert.NoError(t, hdr.validateKDFParams(), "N=%d r=%d p=%d must be accepted", params.N, params.R, params.P)
+	}
+}
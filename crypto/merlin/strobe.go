@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package merlin
+
+// strobe128 is the subset of STROBE-128 (v1.0.2) over Keccak-f[1600] that Merlin transcripts use
+type strobe128 struct {
+	state    [200]byte
+	pos      int
+	posBegin int
+	curFlags byte
+}
+
+const (
+	strobeR = 166
+
+	flagI byte = 1
+	flagA byte = 1 << 1
+	flagC byte = 1 << 2
+	flagT byte = 1 << 3
+	flagM byte = 1 << 4
+	flagK byte = 1 << 5
+)
+
+func newStrobe128(protocolLabel []byte) *strobe128 {
+	s := new(strobe128)
+	copy(s.state[:6], []byte{1, strobeR + 2, 1, 0, 1, 96})
+	copy(s.state[6:18], "STROBEv1.0.2")
+	keccakF1600(&s.state)
+	s.metaAD(protocolLabel, false)
+	return s
+}
+
+func (s *strobe128) clone() *strobe128 {
+	c := *s
+	return &c
+}
+
+func (s *strobe128) metaAD(data []byte, more bool) {
+	s.beginOp(flagM|flagA, more)
+	s.absorb(data)
+}
+
+func (s *strobe128) ad(data []byte, more bool) {
+	s.beginOp(flagA, more)
+	s.absorb(data)
+}
+
+func (s *strobe128) prf(data []byte, more bool) {
+	s.beginOp(flagI|flagA|flagC, more)
+	s.squeeze(data)
+}
+
+func (s *strobe128) key(data []byte, more bool) {
+	s.beginOp(flagA|flagC, more)
+	s.overwrite(data)
+}
+
+func (s *strobe128) runF() {
+	s.state[s.pos] ^= byte(s.posBegin)
+	s.state[s.pos+1] ^= 0x04
+	s.state[strobeR+1] ^= 0x80
+	keccakF1600(&s.state)
+	s.pos, s.posBegin = 0, 0
+}
+
+func (s *strobe128) absorb(data []byte) {
+	for _, b := range data {
+		s.state[s.pos] ^= b
+		s.pos++
+		if s.pos == strobeR {
+			s.runF()
+		}
+	}
+}
+
+func (s *strobe128) overwrite(data []byte) {
+	for _, b := range data {
+		s.state[s.pos] = b
+		s.pos++
+		if s.pos == strobeR {
+			s.runF()
+		}
+	}
+}
+
+func (s *strobe128) squeeze(data []byte) {
+	for i := range data {
+		data[i] = s.state[s.pos]
+		s.state[s.pos] = 0
+		s.pos++
+		if s.pos == strobeR {
+			s.runF()
+		}
+	}
+}
+
+func (s *strobe128) beginOp(flags byte, more bool) {
+	if more {
+		if s.curFlags != flags {
+			panic("strobe128: continued an operation with different flags")
+		}
+		return
+	}
+	if flags&flagT != 0 {
+		panic("strobe128: transport operations are not supported")
+	}
+	oldBegin := s.posBegin
+	s.posBegin = s.pos + 1
+	s.curFlags = flags
+	s.absorb([]byte{byte(oldBegin), flags})
+	// C and K operations must start on a fresh block
+	if flags&(flagC|flagK) != 0 && s.pos != 0 {
+		s.runF()
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package merlin implements Merlin transcripts (https://merlin.cool), the STROBE based Fiat-Shamir transcripts used by
+// Schnorrkel and sr25519.
+package merlin
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const merlinProtocolLabel = "Merlin v1.0"
+
+// Transcript is a Merlin transcript. The zero value is not usable; use NewTranscript.
+type Transcript struct {
+	s *strobe128
+}
+
+// NewTranscript starts a transcript with the domain separator `label`
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{s: newStrobe128([]byte(merlinProtocolLabel))}
+	t.AppendMessage([]byte("dom-sep"), []byte(label))
+	return t
+}
+
+// AppendMessage appends `message` with `label` to the transcript
+func (t *Transcript) AppendMessage(label, message []byte) {
+	t.s.metaAD(label, false)
+	t.s.metaAD(encodeLength(len(message)), true)
+	t.s.ad(message, false)
+}
+
+// ExtractBytes returns `n` challenge bytes with `label` that depend on everything appended so far
+func (t *Transcript) ExtractBytes(label []byte, n int) []byte {
+	t.s.metaAD(label, false)
+	t.s.metaAD(encodeLength(n), true)
+	dest := make([]byte, n)
+	t.s.prf(dest, false)
+	return dest
+}
+
+// Clone returns an independent copy of the transcript
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{s: t.s.clone()}
+}
+
+func encodeLength(n int) []byte {
+	if n < 0 || uint64(n) > 0xffffffff {
+		panic(fmt.Errorf("merlin: length %d does not fit in 32 bits", n))
+	}
+	bz := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bz, uint32(n))
+	return bz
+}
@@ -0,0 +1,22 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package merlin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the test vector of the merlin crate
+func TestTranscriptSimple(t *testing.T) {
+	tr := NewTranscript("test protocol")
+	tr.AppendMessage([]byte("some label"), []byte("some data"))
+	challenge := tr.ExtractBytes([]byte("challenge"), 32)
+	assert.Equal(t, "d5a21972d0d5fe320c0d263fac7fffb8145aa640af6e9bca177c03c7efcf0615", hex.EncodeToString(challenge))
+}
@@ -39,6 +39,11 @@ func ProveBobWC(curve string, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x,
 	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || c1 == nil || c2 == nil || x == nil || y == nil || r == nil {
 		return nil, errors.New("ProveBob() received a nil argument")
 	}
+	return proveBobWC(curve, pk, NTilde, h1, h2, c1, c2, x, y, r, X, pk.NewRandomness())
+}
+
+// proveBobWC is ProveBobWC with the random unit beta of step 4 and beta^N supplied by the caller.
+func proveBobWC(curve string, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x, y, r *big.Int, X *crypto.ECPoint, betaRnd *paillier.Randomness) (*ProofBobWC, error) {
 
 	NSq := pk.NSquare()
 
@@ -61,7 +66,7 @@ func ProveBobWC(curve string, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x,
 	rhoPrm := common.GetRandomPositiveInt(q3NTilde)
 
 	// 4.
-	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	beta := betaRnd.R
 	gamma := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
 
 	// 5.
@@ -87,7 +92,7 @@ func ProveBobWC(curve string, pk *paillier.PublicKey, NTilde, h1, h2, c1, c2, x,
 	modNSq := common.ModInt(NSq)
 	v := modNSq.Exp(c1, alpha)
 	v = modNSq.Mul(v, modNSq.Exp(pk.Gamma(), gamma))
-	v = modNSq.Mul(v, modNSq.Exp(beta, pk.N))
+	v = modNSq.Mul(v, betaRnd.RN)
 
 	// 10.
 	w := modNTilde.Exp(h1, gamma)
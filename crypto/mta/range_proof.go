@@ -35,6 +35,11 @@ func ProveRangeAlice(curve string, pk *paillier.PublicKey, c, NTilde, h1, h2, m,
 	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || c == nil || m == nil || r == nil {
 		return nil, errors.New("ProveRangeAlice constructor received nil value(s)")
 	}
+	return proveRangeAlice(curve, pk, c, NTilde, h1, h2, m, r, pk.NewRandomness())
+}
+
+// proveRangeAlice is ProveRangeAlice with the random unit beta of step 2 and beta^N supplied by the caller.
+func proveRangeAlice(curve string, pk *paillier.PublicKey, c, NTilde, h1, h2, m, r *big.Int, betaRnd *paillier.Randomness) (*RangeProofAlice, error) {
 
 	q := tss.EC(curve).Params().N
 	q3 := new(big.Int).Mul(q, q)
@@ -45,7 +50,7 @@ func ProveRangeAlice(curve string, pk *paillier.PublicKey, c, NTilde, h1, h2, m,
 	// 1.
 	alpha := common.GetRandomPositiveInt(q3)
 	// 2.
-	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	beta := betaRnd.R
 
 	// 3.
 	gamma := common.GetRandomPositiveInt(q3NTilde)
@@ -61,7 +66,7 @@ func ProveRangeAlice(curve string, pk *paillier.PublicKey, c, NTilde, h1, h2, m,
 	// 6.
 	modNSq := common.ModInt(pk.NSquare())
 	u := modNSq.Exp(pk.Gamma(), alpha)
-	u = modNSq.Mul(u, modNSq.Exp(beta, pk.N))
+	u = modNSq.Mul(u, betaRnd.RN)
 
 	// 7.
 	w := modNTilde.Exp(h1, alpha)
@@ -16,27 +16,45 @@ import (
 	"github.com/sisu-network/tss-lib/tss"
 )
 
+// AliceInit proves the range of `a` encrypted as `cA` under pkA. An optional pool for pkA supplies the randomness of
+// the proof, which is otherwise computed online.
 func AliceInit(
 	curve string,
 	pkA *paillier.PublicKey,
 	a, cA, rA, NTildeB, h1B, h2B *big.Int,
+	optionalPool ...*paillier.RandomnessPool,
 ) (pf *RangeProofAlice, err error) {
-	return ProveRangeAlice(curve, pkA, cA, NTildeB, h1B, h2B, a, rA)
+	if pkA == nil || NTildeB == nil || h1B == nil || h2B == nil || cA == nil || a == nil || rA == nil {
+		return nil, errors.New("AliceInit() received nil value(s)")
+	}
+	take, err := randomnessFor(pkA, optionalPool)
+	if err != nil {
+		return
+	}
+	return proveRangeAlice(curve, pkA, cA, NTildeB, h1B, h2B, a, rA, take())
 }
 
+// BobMid verifies Alice's range proof and answers with cB and Bob's proof. An optional pool for pkA supplies the
+// Paillier randomness of the encryption and of the proof, which is otherwise computed online.
 func BobMid(
 	curve string,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
+	optionalPool ...*paillier.RandomnessPool,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
+	take, err := randomnessFor(pkA, optionalPool)
+	if err != nil {
+		return
+	}
 	if !pf.Verify(curve, pkA, NTildeB, h1B, h2B, cA) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
 	q := tss.EC(curve).Params().N
 	betaPrm = common.GetRandomPositiveInt(pkA.N)
-	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
+	cRand := take()
+	cBetaPrm, err := pkA.EncryptWithRandomness(betaPrm, cRand)
 	if err != nil {
 		return
 	}
@@ -47,23 +65,34 @@ func BobMid(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBob(curve, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand)
+	pfWC, err := proveBobWC(curve, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand.R, nil, take())
+	if err != nil {
+		return
+	}
+	piB = pfWC.ProofBob
 	return
 }
 
+// BobMidWC is BobMid with the additional check that B = b*G.
 func BobMidWC(
 	curve string,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
 	B *crypto.ECPoint,
+	optionalPool ...*paillier.RandomnessPool,
 ) (betaPrm, cB *big.Int, piB *ProofBobWC, err error) {
+	take, err := randomnessFor(pkA, optionalPool)
+	if err != nil {
+		return
+	}
 	if !pf.Verify(curve, pkA, NTildeB, h1B, h2B, cA) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
 	betaPrm = common.GetRandomPositiveInt(pkA.N)
-	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
+	cRand := take()
+	cBetaPrm, err := pkA.EncryptWithRandomness(betaPrm, cRand)
 	if err != nil {
 		return
 	}
@@ -75,7 +104,7 @@ func BobMidWC(
 	if err != nil {
 		return
 	}
-	piB, err = ProveBobWC(curve, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B)
+	piB, err = proveBobWC(curve, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand.R, B, take())
 	return
 }
 
@@ -117,3 +146,22 @@ func AliceEndWC(
 	muIJ = new(big.Int).Mod(muIJRec, q)
 	return
 }
+
+// randomnessFor returns the source of Paillier randomness under pk: the pool given in `optionalPool`, or an online
+// computation when there is none. A pool for a different key is an error.
+func randomnessFor(pk *paillier.PublicKey, optionalPool []*paillier.RandomnessPool) (func() *paillier.Randomness, error) {
+	if pk == nil || pk.N == nil {
+		return nil, errors.New("the Paillier public key is nil")
+	}
+	if 1 < len(optionalPool) {
+		return nil, errors.New("expected 0 or 1 item in `optionalPool`")
+	}
+	if 0 == len(optionalPool) || optionalPool[0] == nil {
+		return pk.NewRandomness, nil
+	}
+	pool := optionalPool[0]
+	if pool.PublicKey().N.Cmp(pk.N) != 0 {
+		return nil, errors.New("the randomness pool was made for a different Paillier key")
+	}
+	return pool.Take, nil
+}
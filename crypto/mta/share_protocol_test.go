@@ -92,3 +92,47 @@ func TestShareProtocolWC(t *testing.T) {
 	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
 	assert.Equal(t, 0, muIJ.Cmp(aTimesBPlusBetaModQ))
 }
+
+func TestShareProtocolWithRandomnessPool(t *testing.T) {
+	q := tss.EC("").Params().N
+
+	sk, pk, err := paillier.GenerateKeyPair(testPaillierKeyLength, 10*time.Minute)
+	assert.NoError(t, err)
+	pool := paillier.NewRandomnessPool(pk, 4)
+	defer pool.Close()
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+
+	NTildei, h1i, h2i, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := keygen.LoadNTildeH1H2FromTestFixture(1)
+	assert.NoError(t, err)
+
+	rnd := pool.Take()
+	cA, err := pk.EncryptWithRandomness(a, rnd)
+	assert.NoError(t, err)
+	pf, err := AliceInit("", pk, a, cA, rnd.R, NTildej, h1j, h2j, pool)
+	assert.NoError(t, err)
+
+	_, cB, betaPrm, pfB, err := BobMid("", pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, pool)
+	assert.NoError(t, err)
+
+	alpha, err := AliceEnd("", pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
+	assert.NoError(t, err)
+
+	// expect: alpha = ab + betaPrm
+	aTimesB := new(big.Int).Mul(a, b)
+	aTimesBPlusBeta := new(big.Int).Add(aTimesB, betaPrm)
+	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
+	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
+
+	// a pool made for another key must be refused
+	otherPK := &paillier.PublicKey{N: new(big.Int).Add(pk.N, big.NewInt(2))}
+	otherPool := paillier.NewRandomnessPool(otherPK, 1)
+	defer otherPool.Close()
+	_, err = AliceInit("", pk, a, cA, rnd.R, NTildej, h1j, h2j, otherPool)
+	assert.Error(t, err)
+	_, _, _, _, err = BobMid("", pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, otherPool)
+	assert.Error(t, err)
+}
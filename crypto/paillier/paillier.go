@@ -45,6 +45,19 @@ type (
 		PublicKey
 		LambdaN, // lcm(p-1, q-1)
 		PhiN *big.Int // (p-1) * (q-1)
+
+		crt *crtValues // set by Precompute()
+	}
+
+	// crtValues holds the factors of N and the values derived from them that let the key owner decrypt and encrypt
+	// modulo p^2 and q^2 instead of N^2
+	crtValues struct {
+		p, q, pSq, qSq *big.Int
+		hP, hQ         *big.Int // Lp(Gamma^(p-1) mod p^2)^-1 mod p, and likewise for q
+		pInvQ          *big.Int // p^-1 mod q
+		pSqInvQSq      *big.Int // (p^2)^-1 mod q^2
+		nModPhiPSq,
+		nModPhiQSq *big.Int // N mod p(p-1), N mod q(q-1)
 	}
 
 	// Proof uses the new GenerateXs method in GG18Spec (6)
@@ -56,6 +69,7 @@ var (
 
 	zero = big.NewInt(0)
 	one  = big.NewInt(1)
+	two  = big.NewInt(2)
 )
 
 func init() {
@@ -103,6 +117,7 @@ func GenerateKeyPair(modulusBitLen int, timeout time.Duration, optionalConcurren
 
 	publicKey = &PublicKey{N: N}
 	privateKey = &PrivateKey{PublicKey: *publicKey, LambdaN: lambdaN, PhiN: phiN}
+	privateKey.crt = newCRTValues(N, P, Q)
 	return
 }
 
@@ -186,13 +201,41 @@ func (pk *PublicKey) Gamma() *big.Int {
 
 // ----- //
 
+// Precompute stores the factors of N and the values derived from them in the key, so that later calls to Decrypt
+// and to the encryption methods of the PrivateKey do not have to derive them again. GenerateKeyPair returns keys that
+// are already precomputed; call it once on a key that was unmarshalled, before the key is shared between goroutines.
+// It does nothing on a key that is already precomputed.
+func (sk *PrivateKey) Precompute() error {
+	if sk.crt != nil {
+		return nil
+	}
+	P, Q, err := sk.factorN()
+	if err != nil {
+		return err
+	}
+	sk.crt = newCRTValues(sk.N, P, Q)
+	return nil
+}
+
+// Decrypt returns the plaintext of `c`. It works modulo p^2 and q^2 and recombines the two halves with the
+// Chinese Remainder Theorem, which gives the same result as L(c^LambdaN mod N2) / L(Gamma^LambdaN mod N2) mod N.
 func (sk *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
-	NSq := sk.NSquare()
-	modN := common.ModInt(sk.N)
-	modNSq := common.ModInt(NSq)
-	if c.Cmp(zero) == -1 || c.Cmp(NSq) != -1 { // c < 0 || c >= N2 ?
+	if c.Cmp(zero) == -1 || c.Cmp(sk.NSquare()) != -1 { // c < 0 || c >= N2 ?
 		return nil, ErrMessageTooLong
 	}
+	if crt := sk.crtValues(); crt != nil {
+		if m = crt.decrypt(c); m != nil {
+			return
+		}
+	}
+	return sk.decryptModNSquare(c)
+}
+
+// decryptModNSquare is the textbook decryption over N^2. It remains for the inputs that are not units mod N, where
+// the CRT path does not apply.
+func (sk *PrivateKey) decryptModNSquare(c *big.Int) (m *big.Int, err error) {
+	modN := common.ModInt(sk.N)
+	modNSq := common.ModInt(sk.NSquare())
 	// 1. L(u) = (c^LambdaN-1 mod N2) / N
 	Lc := L(modNSq.Exp(c, sk.LambdaN), sk.N)
 	// 2. L(u) = (Gamma^LambdaN-1 mod N2) / N
@@ -203,6 +246,42 @@ func (sk *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	return
 }
 
+// EncryptWithChosenRandomness gives the same result as PublicKey.EncryptWithChosenRandomness, but computes x^N
+// modulo p^2 and q^2 and uses Gamma^m = 1 + m*N mod N2.
+func (sk *PrivateKey) EncryptWithChosenRandomness(m, x *big.Int) (c *big.Int, err error) {
+	if x == nil || x.Cmp(zero) == 0 {
+		return nil, errors.New("EncryptWithChosenRandomness() requires non-zero randomness")
+	}
+	if m.Cmp(zero) == -1 || m.Cmp(sk.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
+	crt := sk.crtValues()
+	if crt == nil {
+		return sk.PublicKey.EncryptWithChosenRandomness(m, x)
+	}
+	return crt.encrypt(sk.N, m, x), nil
+}
+
+// EncryptAndReturnRandomness gives the same result as PublicKey.EncryptAndReturnRandomness, using the CRT like
+// EncryptWithChosenRandomness.
+func (sk *PrivateKey) EncryptAndReturnRandomness(m *big.Int) (c *big.Int, x *big.Int, err error) {
+	if m.Cmp(zero) == -1 || m.Cmp(sk.N) != -1 { // m < 0 || m >= N ?
+		return nil, nil, ErrMessageTooLong
+	}
+	crt := sk.crtValues()
+	if crt == nil {
+		return sk.PublicKey.EncryptAndReturnRandomness(m)
+	}
+	x = common.GetRandomPositiveRelativelyPrimeInt(sk.N)
+	c = crt.encrypt(sk.N, m, x)
+	return
+}
+
+func (sk *PrivateKey) Encrypt(m *big.Int) (c *big.Int, err error) {
+	c, _, err = sk.EncryptAndReturnRandomness(m)
+	return
+}
+
 func (sk *PrivateKey) DecryptAndRecoverRandomness(c *big.Int) (m, x *big.Int, err error) {
 	if m, err = sk.Decrypt(c); err != nil {
 		return
@@ -220,6 +299,118 @@ func (sk *PrivateKey) DecryptAndRecoverRandomness(c *big.Int) (m, x *big.Int, er
 	return
 }
 
+// Validate checks that N, PhiN and LambdaN belong to the same key: N must factor as p*q with (p-1)*(q-1) = PhiN,
+// and LambdaN must be lcm(p-1, q-1).
+func (sk *PrivateKey) Validate() error {
+	if sk.N == nil || sk.LambdaN == nil || sk.PhiN == nil {
+		return errors.New("paillier private key is missing N, LambdaN or PhiN")
+	}
+	P, Q, err := sk.factorN()
+	if err != nil {
+		return err
+	}
+	PMinus1, QMinus1 := new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one)
+	gcd := new(big.Int).GCD(nil, nil, PMinus1, QMinus1)
+	if new(big.Int).Div(sk.PhiN, gcd).Cmp(sk.LambdaN) != 0 {
+		return errors.New("paillier LambdaN is not lcm(p-1, q-1)")
+	}
+	return nil
+}
+
+// factorN recovers the primes of N from PhiN: p+q = N - PhiN + 1 and p*q = N, so p and q are the roots of
+// x^2 - (p+q)x + N.
+func (sk *PrivateKey) factorN() (P, Q *big.Int, err error) {
+	sum := new(big.Int).Sub(sk.N, sk.PhiN)
+	sum.Add(sum, one)
+	disc := new(big.Int).Mul(sum, sum)
+	disc.Sub(disc, new(big.Int).Lsh(sk.N, 2))
+	if disc.Sign() <= 0 {
+		return nil, nil, errors.New("paillier PhiN does not match N")
+	}
+	root := new(big.Int).Sqrt(disc)
+	if new(big.Int).Mul(root, root).Cmp(disc) != 0 {
+		return nil, nil, errors.New("paillier PhiN does not match N")
+	}
+	P = new(big.Int).Add(sum, root)
+	P.Div(P, two)
+	Q = new(big.Int).Sub(sum, root)
+	Q.Div(Q, two)
+	if Q.Cmp(one) <= 0 || new(big.Int).Mul(P, Q).Cmp(sk.N) != 0 {
+		return nil, nil, errors.New("paillier PhiN does not match N")
+	}
+	return P, Q, nil
+}
+
+// crtValues returns the precomputed values, or derives them for this call only when Precompute() has not been run.
+// It returns nil when PhiN does not factor N.
+func (sk *PrivateKey) crtValues() *crtValues {
+	if sk.crt != nil {
+		return sk.crt
+	}
+	P, Q, err := sk.factorN()
+	if err != nil {
+		return nil
+	}
+	return newCRTValues(sk.N, P, Q)
+}
+
+func newCRTValues(N, P, Q *big.Int) *crtValues {
+	crt := &crtValues{p: P, q: Q}
+	crt.pSq, crt.qSq = new(big.Int).Mul(P, P), new(big.Int).Mul(Q, Q)
+	crt.hP, crt.hQ = crtH(N, P, crt.pSq), crtH(N, Q, crt.qSq)
+	crt.pInvQ = new(big.Int).ModInverse(P, Q)
+	crt.pSqInvQSq = new(big.Int).ModInverse(crt.pSq, crt.qSq)
+	// phi(p^2) = p(p-1)
+	crt.nModPhiPSq = new(big.Int).Mod(N, new(big.Int).Sub(crt.pSq, P))
+	crt.nModPhiQSq = new(big.Int).Mod(N, new(big.Int).Sub(crt.qSq, Q))
+	return crt
+}
+
+// crtH returns Lp(Gamma^(p-1) mod p^2)^-1 mod p
+func crtH(N, P, PSq *big.Int) *big.Int {
+	Gamma := new(big.Int).Add(N, one)
+	PMinus1 := new(big.Int).Sub(P, one)
+	Lg := L(new(big.Int).Exp(Gamma, PMinus1, PSq), P)
+	return Lg.ModInverse(Lg, P)
+}
+
+// decrypt returns nil when `c` shares a factor with N
+func (crt *crtValues) decrypt(c *big.Int) *big.Int {
+	cP, cQ := new(big.Int).Mod(c, crt.pSq), new(big.Int).Mod(c, crt.qSq)
+	if new(big.Int).Mod(cP, crt.p).Sign() == 0 || new(big.Int).Mod(cQ, crt.q).Sign() == 0 {
+		return nil
+	}
+	// mp = Lp(c^(p-1) mod p^2) * hp mod p
+	mP := L(cP.Exp(cP, new(big.Int).Sub(crt.p, one), crt.pSq), crt.p)
+	mP.Mul(mP, crt.hP).Mod(mP, crt.p)
+	mQ := L(cQ.Exp(cQ, new(big.Int).Sub(crt.q, one), crt.qSq), crt.q)
+	mQ.Mul(mQ, crt.hQ).Mod(mQ, crt.q)
+	return crtCombine(mP, mQ, crt.p, crt.q, crt.pInvQ)
+}
+
+// encrypt computes Gamma^m * x^N mod N2 with Gamma^m = 1 + m*N and x^N recombined from x^N mod p^2 and mod q^2.
+// N mod phi(p^2) is at least p, so the reduced exponent also gives 0 when p divides x.
+func (crt *crtValues) encrypt(N, m, x *big.Int) *big.Int {
+	xP := new(big.Int).Mod(x, crt.pSq)
+	xP.Exp(xP, crt.nModPhiPSq, crt.pSq)
+	xQ := new(big.Int).Mod(x, crt.qSq)
+	xQ.Exp(xQ, crt.nModPhiQSq, crt.qSq)
+	xN := crtCombine(xP, xQ, crt.pSq, crt.qSq, crt.pSqInvQSq)
+
+	modNSq := common.ModInt(new(big.Int).Mul(N, N))
+	Gm := new(big.Int).Mul(m, N)
+	Gm.Add(Gm, one)
+	return modNSq.Mul(Gm, xN)
+}
+
+// crtCombine returns the z mod a*b with z = za mod a and z = zb mod b, given aInvB = a^-1 mod b
+func crtCombine(za, zb, a, b, aInvB *big.Int) *big.Int {
+	// z = za + a * ((zb - za) * a^-1 mod b)
+	h := new(big.Int).Sub(zb, za)
+	h.Mul(h, aInvB).Mod(h, b)
+	return h.Mul(h, a).Add(h, za)
+}
+
 // ----- //
 
 // Proof is an implementation of Gennaro, R., Micciancio, D., Rabin, T.:
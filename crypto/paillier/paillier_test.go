@@ -7,6 +7,7 @@
 package paillier_test
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 	"time"
@@ -205,3 +206,145 @@ func TestGenerateXs(t *testing.T) {
 		assert.True(t, common.IsNumberInMultiplicativeGroup(N, xi))
 	}
 }
+
+func TestPrivateKeyValidate(t *testing.T) {
+	setUp(t)
+	assert.NoError(t, privateKey.Validate())
+
+	bad := *privateKey
+	bad.PhiN = new(big.Int).Sub(privateKey.PhiN, big.NewInt(2))
+	assert.Error(t, bad.Validate())
+
+	bad = *privateKey
+	bad.LambdaN = new(big.Int).Add(privateKey.LambdaN, big.NewInt(1))
+	assert.Error(t, bad.Validate())
+}
+
+func TestCRTMatchesModNSquare(t *testing.T) {
+	setUp(t)
+	// a key without Precompute() derives the CRT values on each call
+	loaded := &PrivateKey{PublicKey: privateKey.PublicKey, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN}
+	for i := 0; i < 10; i++ {
+		m := common.GetRandomPositiveInt(privateKey.N)
+		x := common.GetRandomPositiveRelativelyPrimeInt(privateKey.N)
+		c, err := publicKey.EncryptWithChosenRandomness(m, x)
+		assert.NoError(t, err)
+		for _, sk := range []*PrivateKey{privateKey, loaded} {
+			cCRT, err := sk.EncryptWithChosenRandomness(m, x)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, c.Cmp(cCRT), "CRT encryption must match the public key encryption")
+			ret, err := sk.Decrypt(c)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, m.Cmp(ret))
+		}
+	}
+	// inputs that are not units mod N take the N^2 path and must still give the same results
+	for _, c := range []*big.Int{big.NewInt(0), privateKey.N, new(big.Int).Mul(privateKey.N, big.NewInt(3))} {
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, decryptModNSquare(privateKey, c).Cmp(ret))
+	}
+	m, x := big.NewInt(7), new(big.Int).Set(privateKey.N)
+	c, err := publicKey.EncryptWithChosenRandomness(m, x)
+	assert.NoError(t, err)
+	cCRT, err := privateKey.EncryptWithChosenRandomness(m, x)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.Cmp(cCRT))
+
+	assert.NoError(t, loaded.Precompute())
+	bad := &PrivateKey{PublicKey: privateKey.PublicKey, LambdaN: privateKey.LambdaN, PhiN: big.NewInt(12)}
+	assert.Error(t, bad.Precompute())
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	benchSetUp(b)
+	c, _ := publicKey.Encrypt(common.GetRandomPositiveInt(publicKey.N))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = privateKey.Decrypt(c)
+	}
+}
+
+// BenchmarkDecryptUnmarshalled decrypts with a key read back from JSON, as from save data, which does not carry the
+// CRT values
+func BenchmarkDecryptUnmarshalled(b *testing.B) {
+	benchDecryptUnmarshalled(b, false)
+}
+
+// BenchmarkDecryptUnmarshalledPrecomputed is BenchmarkDecryptUnmarshalled after Precompute(), as done by
+// keygen.LoadLocalPartySaveData and by presigning
+func BenchmarkDecryptUnmarshalledPrecomputed(b *testing.B) {
+	benchDecryptUnmarshalled(b, true)
+}
+
+func BenchmarkDecryptModNSquare(b *testing.B) {
+	benchSetUp(b)
+	c, _ := publicKey.Encrypt(common.GetRandomPositiveInt(publicKey.N))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = decryptModNSquare(privateKey, c)
+	}
+}
+
+func BenchmarkEncryptPrivateKey(b *testing.B) {
+	benchSetUp(b)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	x := common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = privateKey.EncryptWithChosenRandomness(m, x)
+	}
+}
+
+func BenchmarkEncryptPublicKey(b *testing.B) {
+	benchSetUp(b)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	x := common.GetRandomPositiveRelativelyPrimeInt(publicKey.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = publicKey.EncryptWithChosenRandomness(m, x)
+	}
+}
+
+// ----- //
+
+func benchSetUp(b *testing.B) {
+	if privateKey != nil {
+		return
+	}
+	var err error
+	if privateKey, publicKey, err = GenerateKeyPair(testPaillierKeyLength, 10*time.Minute); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func benchDecryptUnmarshalled(b *testing.B, precompute bool) {
+	benchSetUp(b)
+	bz, err := json.Marshal(privateKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+	loaded := new(PrivateKey)
+	if err = json.Unmarshal(bz, loaded); err != nil {
+		b.Fatal(err)
+	}
+	if precompute {
+		if err = loaded.Precompute(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	c, _ := publicKey.Encrypt(common.GetRandomPositiveInt(publicKey.N))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = loaded.Decrypt(c)
+	}
+}
+
+// decryptModNSquare is the textbook decryption L(c^LambdaN mod N2) / L(Gamma^LambdaN mod N2) mod N
+func decryptModNSquare(sk *PrivateKey, c *big.Int) *big.Int {
+	NSq := sk.NSquare()
+	Lc := L(new(big.Int).Exp(c, sk.LambdaN, NSq), sk.N)
+	Lg := L(new(big.Int).Exp(sk.Gamma(), sk.LambdaN, NSq), sk.N)
+	inv := new(big.Int).ModInverse(Lg, sk.N)
+	return Lc.Mul(Lc, inv).Mod(Lc, sk.N)
+}
@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/sisu-network/tss-lib/common"
+)
+
+type (
+	// Randomness is a random unit r mod N together with r^N mod N2, the costly part of an encryption under N
+	Randomness struct {
+		R, RN *big.Int
+	}
+
+	// RandomnessPool precomputes Randomness for one public key in the background. Every value is handed out once:
+	// Take removes it from the pool, and computes a fresh value online when the pool is empty.
+	RandomnessPool struct {
+		pk     *PublicKey
+		values chan *Randomness
+		quit   chan struct{}
+		once   sync.Once
+		wg     sync.WaitGroup
+	}
+
+	// RandomnessStore holds a RandomnessPool for each public key it has been asked about. A nil store is valid and
+	// returns nil pools, which makes the callers compute all randomness online.
+	RandomnessStore struct {
+		mtx         sync.Mutex
+		size        int
+		concurrency int
+		pools       map[string]*RandomnessPool
+	}
+)
+
+// NewRandomness picks r at random from the units mod N and computes r^N mod N2.
+func (pk *PublicKey) NewRandomness() *Randomness {
+	r := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	return &Randomness{R: r, RN: new(big.Int).Exp(r, pk.N, pk.NSquare())}
+}
+
+// EncryptWithRandomness gives the same result as EncryptWithChosenRandomness(m, rnd.R) without the exponentiation
+// of rnd.R, which must have been made for this key.
+func (pk *PublicKey) EncryptWithRandomness(m *big.Int, rnd *Randomness) (c *big.Int, err error) {
+	if rnd == nil || rnd.R == nil || rnd.RN == nil || rnd.R.Sign() == 0 {
+		return nil, errors.New("EncryptWithRandomness() requires non-zero randomness")
+	}
+	if m.Cmp(zero) == -1 || m.Cmp(pk.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
+	modNSq := common.ModInt(pk.NSquare())
+	// Gamma^m = (N+1)^m = 1 + m*N mod N2
+	Gm := new(big.Int).Mul(m, pk.N)
+	Gm.Add(Gm, one)
+	c = modNSq.Mul(Gm, rnd.RN)
+	return
+}
+
+// ----- //
+
+// NewRandomnessPool starts `concurrency` goroutines (default 1) that keep up to `size` values ready for `pk`.
+// Close stops them.
+func NewRandomnessPool(pk *PublicKey, size int, optionalConcurrency ...int) *RandomnessPool {
+	if size < 1 {
+		panic(errors.New("NewRandomnessPool: expected a size of at least 1"))
+	}
+	concurrency := 1
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("NewRandomnessPool: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	}
+	pool := &RandomnessPool{
+		pk:     &PublicKey{N: new(big.Int).Set(pk.N)},
+		values: make(chan *Randomness, size),
+		quit:   make(chan struct{}),
+	}
+	pool.wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go pool.fill()
+	}
+	return pool
+}
+
+// PublicKey returns the key that the pool makes randomness for.
+func (pool *RandomnessPool) PublicKey() *PublicKey {
+	return pool.pk
+}
+
+// Take returns a value that no other caller has received or will receive.
+func (pool *RandomnessPool) Take() *Randomness {
+	select {
+	case rnd := <-pool.values:
+		return rnd
+	default:
+		return pool.pk.NewRandomness()
+	}
+}
+
+// Len returns the number of values that are ready.
+func (pool *RandomnessPool) Len() int {
+	return len(pool.values)
+}
+
+// Close stops the background workers and drops the values that were not taken.
+func (pool *RandomnessPool) Close() {
+	pool.once.Do(func() {
+		close(pool.quit)
+		pool.wg.Wait()
+		for {
+			select {
+			case <-pool.values:
+			default:
+				return
+			}
+		}
+	})
+}
+
+func (pool *RandomnessPool) fill() {
+	defer pool.wg.Done()
+	for {
+		select {
+		case <-pool.quit:
+			return
+		default:
+		}
+		rnd := pool.pk.NewRandomness()
+		select {
+		case pool.values <- rnd:
+		case <-pool.quit:
+			return
+		}
+	}
+}
+
+// ----- //
+
+// NewRandomnessStore returns a store whose pools each keep up to `size` values, filled by `concurrency` goroutines
+// (default 1) per pool.
+func NewRandomnessStore(size int, optionalConcurrency ...int) *RandomnessStore {
+	if size < 1 {
+		panic(errors.New("NewRandomnessStore: expected a size of at least 1"))
+	}
+	concurrency := 1
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("NewRandomnessStore: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	}
+	return &RandomnessStore{size: size, concurrency: concurrency, pools: make(map[string]*RandomnessPool)}
+}
+
+// Pool returns the pool for `pk`, starting it if the store did not have one yet. Call it ahead of time for the keys
+// of the other parties so that their pools are full when the protocol runs.
+func (store *RandomnessStore) Pool(pk *PublicKey) *RandomnessPool {
+	if store == nil || pk == nil || pk.N == nil {
+		return nil
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	id := string(pk.N.Bytes())
+	pool, ok := store.pools[id]
+	if !ok {
+		pool = NewRandomnessPool(pk, store.size, store.concurrency)
+		store.pools[id] = pool
+	}
+	return pool
+}
+
+// Close closes every pool in the store.
+func (store *RandomnessStore) Close() {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	for id, pool := range store.pools {
+		pool.Close()
+		delete(store.pools, id)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier_test
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	. "github.com/sisu-network/tss-lib/crypto/paillier"
+)
+
+func TestEncryptWithRandomness(t *testing.T) {
+	setUp(t)
+	m := common.GetRandomPositiveInt(publicKey.N)
+	rnd := publicKey.NewRandomness()
+	c, err := publicKey.EncryptWithRandomness(m, rnd)
+	assert.NoError(t, err)
+	expected, err := publicKey.EncryptWithChosenRandomness(m, rnd.R)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expected.Cmp(c))
+
+	dec, err := privateKey.Decrypt(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(dec))
+
+	_, err = publicKey.EncryptWithRandomness(m, &Randomness{R: big.NewInt(0), RN: big.NewInt(0)})
+	assert.Error(t, err, "must reject zero randomness")
+	_, err = publicKey.EncryptWithRandomness(publicKey.N, rnd)
+	assert.Error(t, err, "must reject a message >= N")
+}
+
+func TestRandomnessPoolTakeOnce(t *testing.T) {
+	setUp(t)
+	const size, takes = 8, 64
+	pool := NewRandomnessPool(publicKey, size, 2)
+	defer pool.Close()
+
+	// wait for the pool to fill up in the background
+	for deadline := time.Now().Add(time.Minute); pool.Len() < size && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, size, pool.Len())
+
+	// concurrent takers, more than the pool holds, must never receive the same value twice
+	var mtx sync.Mutex
+	seen := make(map[string]bool, takes)
+	wg := sync.WaitGroup{}
+	wg.Add(takes)
+	for k := 0; k < takes; k++ {
+		go func() {
+			defer wg.Done()
+			rnd := pool.Take()
+			rN := new(big.Int).Exp(rnd.R, publicKey.N, publicKey.NSquare())
+			assert.Equal(t, 0, rN.Cmp(rnd.RN), "RN must be R^N mod N2")
+			mtx.Lock()
+			defer mtx.Unlock()
+			assert.False(t, seen[rnd.R.String()], "randomness must be used once")
+			seen[rnd.R.String()] = true
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, takes)
+
+	pool.Close()
+	assert.Equal(t, 0, pool.Len(), "Close must drop the remaining values")
+	assert.NotNil(t, pool.Take(), "a closed pool computes randomness online")
+}
+
+func TestRandomnessStore(t *testing.T) {
+	setUp(t)
+	var nilStore *RandomnessStore
+	assert.Nil(t, nilStore.Pool(publicKey))
+
+	store := NewRandomnessStore(1)
+	defer store.Close()
+	pool := store.Pool(publicKey)
+	assert.Equal(t, 0, pool.PublicKey().N.Cmp(publicKey.N))
+	assert.True(t, pool == store.Pool(&PublicKey{N: new(big.Int).Set(publicKey.N)}), "one pool per key")
+}
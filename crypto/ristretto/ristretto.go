@@ -0,0 +1,152 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ristretto implements the Ristretto255 encoding of RFC 9496 for the points of edwards25519. Ristretto255
+// is a prime order group: points of edwards25519 that differ by a point of small order have the same encoding.
+// The group operations are those of crypto.ECPoint on the "eddsa" curve.
+package ristretto
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// EncodedLength is the length of an encoded point
+const EncodedLength = 32
+
+var (
+	fieldP = tss.EC("eddsa").Params().P
+	one    = big.NewInt(1)
+
+	// d = -121665/121666, the parameter of edwards25519
+	paramD = fe().Mul(fe().Neg(big.NewInt(121665)), fe().ModInverse(big.NewInt(121666), fieldP))
+	// sqrt(-1) = 2^((p-1)/4)
+	sqrtM1 = fe().Exp(big.NewInt(2), fe().Rsh(fe().Sub(fieldP, one), 2), fieldP)
+	// 1/sqrt(a-d) with a = -1
+	invSqrtAMinusD *big.Int
+)
+
+func init() {
+	paramD.Mod(paramD, fieldP)
+	_, invSqrtAMinusD = sqrtRatioM1(one, mod(fe().Sub(big.NewInt(-1), paramD)))
+}
+
+// Encode returns the Ristretto255 encoding of a point of edwards25519
+func Encode(p *crypto.ECPoint) []byte {
+	// the affine point in extended coordinates, Z = 1
+	x0, y0, z0 := p.X(), p.Y(), big.NewInt(1)
+	t0 := mul(x0, y0)
+
+	u1 := mul(add(z0, y0), sub(z0, y0))
+	u2 := mul(x0, y0)
+	_, invSqrt := sqrtRatioM1(one, mul(u1, mul(u2, u2)))
+	den1 := mul(invSqrt, u1)
+	den2 := mul(invSqrt, u2)
+	zInv := mul(mul(den1, den2), t0)
+	ix0 := mul(x0, sqrtM1)
+	iy0 := mul(y0, sqrtM1)
+	enchantedDenominator := mul(den1, invSqrtAMinusD)
+
+	x, y, denInv := x0, y0, den2
+	if isNegative(mul(t0, zInv)) {
+		x, y, denInv = iy0, ix0, enchantedDenominator
+	}
+	if isNegative(mul(x, zInv)) {
+		y = neg(y)
+	}
+	s := abs(mul(denInv, sub(z0, y)))
+
+	bz := s.FillBytes(make([]byte, EncodedLength))
+	reverse(bz)
+	return bz
+}
+
+// Decode returns a point of edwards25519 with the Ristretto255 encoding `bz`. Only canonical encodings are accepted.
+func Decode(bz []byte) (*crypto.ECPoint, error) {
+	if len(bz) != EncodedLength {
+		return nil, errors.New("ristretto: an encoding must be 32 bytes")
+	}
+	le := append([]byte(nil), bz...)
+	reverse(le)
+	s := new(big.Int).SetBytes(le)
+	if s.Cmp(fieldP) >= 0 || isNegative(s) {
+		return nil, errors.New("ristretto: the encoding is not canonical")
+	}
+
+	ss := mul(s, s)
+	u1 := sub(one, ss)
+	u2 := add(one, ss)
+	u2Sqr := mul(u2, u2)
+	v := sub(neg(mul(paramD, mul(u1, u1))), u2Sqr)
+	wasSquare, invSqrt := sqrtRatioM1(one, mul(v, u2Sqr))
+	denX := mul(invSqrt, u2)
+	denY := mul(mul(invSqrt, denX), v)
+	x := abs(mul(mul(big.NewInt(2), s), denX))
+	y := mul(u1, denY)
+	t := mul(x, y)
+	if !wasSquare || isNegative(t) || y.Sign() == 0 {
+		return nil, errors.New("ristretto: the encoding is not a valid point")
+	}
+	return crypto.NewECPoint(tss.EC("eddsa"), x, y)
+}
+
+// Equal returns true when `p` and `q` are the same element of Ristretto255
+func Equal(p, q *crypto.ECPoint) bool {
+	if p == nil || q == nil {
+		return false
+	}
+	// x1*y2 == y1*x2 || y1*y2 == x1*x2
+	return mul(p.X(), q.Y()).Cmp(mul(p.Y(), q.X())) == 0 || mul(p.Y(), q.Y()).Cmp(mul(p.X(), q.X())) == 0
+}
+
+// ----- //
+
+// sqrtRatioM1 returns (true, sqrt(u/v)) when u/v is square and (false, sqrt(i*u/v)) otherwise; the root is not negative
+func sqrtRatioM1(u, v *big.Int) (bool, *big.Int) {
+	v3 := mul(mul(v, v), v)
+	v7 := mul(mul(v3, v3), v)
+	exp := fe().Rsh(fe().Sub(fieldP, big.NewInt(5)), 3)
+	r := mul(mul(u, v3), fe().Exp(mul(u, v7), exp, fieldP))
+	check := mul(v, mul(r, r))
+
+	correctSignSqrt := check.Cmp(u) == 0
+	flippedSignSqrt := check.Cmp(neg(u)) == 0
+	flippedSignSqrtI := check.Cmp(neg(mul(u, sqrtM1))) == 0
+	if flippedSignSqrt || flippedSignSqrtI {
+		r = mul(r, sqrtM1)
+	}
+	return correctSignSqrt || flippedSignSqrt, abs(r)
+}
+
+func fe() *big.Int { return new(big.Int) }
+
+func mod(a *big.Int) *big.Int { return a.Mod(a, fieldP) }
+
+func add(a, b *big.Int) *big.Int { return mod(fe().Add(a, b)) }
+
+func sub(a, b *big.Int) *big.Int { return mod(fe().Sub(a, b)) }
+
+func mul(a, b *big.Int) *big.Int { return mod(fe().Mul(a, b)) }
+
+func neg(a *big.Int) *big.Int { return mod(fe().Neg(a)) }
+
+func isNegative(a *big.Int) bool { return mod(fe().Set(a)).Bit(0) == 1 }
+
+func abs(a *big.Int) *big.Int {
+	if isNegative(a) {
+		return neg(a)
+	}
+	return mod(fe().Set(a))
+}
+
+func reverse(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// the encodings of the multiples 1 to 4 of the generator, from RFC 9496, Appendix A.1
+var generatorMultiples = []string{
+	"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+	"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+	"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+}
+
+func TestGeneratorMultiples(t *testing.T) {
+	ec := tss.EC("eddsa")
+	for i, expected := range generatorMultiples {
+		P := crypto.ScalarBaseMult(ec, big.NewInt(int64(i+1)))
+		assert.Equal(t, expected, hex.EncodeToString(Encode(P)), "encoding of %d*G", i+1)
+
+		bz, _ := hex.DecodeString(expected)
+		Q, err := Decode(bz)
+		if assert.NoError(t, err) {
+			assert.True(t, Equal(P, Q))
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	ec := tss.EC("eddsa")
+	for i := 0; i < 16; i++ {
+		P := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(ec.Params().N))
+		Q, err := Decode(Encode(P))
+		if assert.NoError(t, err) {
+			assert.True(t, Equal(P, Q))
+			assert.Equal(t, Encode(P), Encode(Q))
+		}
+		// a point of small order does not change the encoding
+		if R, err := P.Add(lowOrderPoint(t)); assert.NoError(t, err) {
+			assert.Equal(t, Encode(P), Encode(R))
+			assert.True(t, Equal(P, R))
+		}
+	}
+}
+
+// the invalid encodings from RFC 9496, Appendix A.2
+func TestDecodeInvalid(t *testing.T) {
+	for _, invalid := range []string{
+		// non-canonical field encodings
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// non-square x^2
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+		"4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f",
+	} {
+		bz, _ := hex.DecodeString(invalid)
+		_, err := Decode(bz)
+		assert.Error(t, err, "%s must not decode", invalid)
+	}
+}
+
+// lowOrderPoint returns (0, -1), the point of order 2
+func lowOrderPoint(t *testing.T) *crypto.ECPoint {
+	ec := tss.EC("eddsa")
+	P, err := crypto.NewECPoint(ec, big.NewInt(0), new(big.Int).Sub(ec.Params().P, big.NewInt(1)))
+	assert.NoError(t, err)
+	return P
+}
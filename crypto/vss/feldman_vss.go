@@ -144,6 +144,39 @@ func (shares Shares) ReConstruct(curve string) (secret *big.Int, err error) {
 	return secret, nil
 }
 
+// InterpolatePoint evaluates at `x` the polynomial in the exponent that passes through the points (ids[i], points[i]),
+// e.g. the public key when x = 0 and points are the public shares X_j = x_j*G of t+1 parties.
+func InterpolatePoint(curve string, ids []*big.Int, points []*crypto.ECPoint, x *big.Int) (*crypto.ECPoint, error) {
+	if len(ids) == 0 || len(ids) != len(points) {
+		return nil, errors.New("InterpolatePoint: expected one point for each id")
+	}
+	modN := common.ModInt(tss.EC(curve).Params().N)
+	var result *crypto.ECPoint
+	for i, id := range ids {
+		coef := one
+		for j, idj := range ids {
+			if j == i {
+				continue
+			}
+			den := modN.Sub(id, idj)
+			if den.Sign() == 0 {
+				return nil, errors.New("InterpolatePoint: duplicate ids")
+			}
+			coef = modN.Mul(coef, modN.Mul(modN.Sub(x, idj), modN.Inverse(den)))
+		}
+		term := points[i].SetCurve(tss.EC(curve)).ScalarMult(coef)
+		if result == nil {
+			result = term
+			continue
+		}
+		var err error
+		if result, err = result.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 func samplePolynomial(curve string, threshold int, secret *big.Int) []*big.Int {
 	q := tss.EC(curve).Params().N
 	v := make([]*big.Int, threshold+1)
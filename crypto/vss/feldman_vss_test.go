@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
 	. "github.com/sisu-network/tss-lib/crypto/vss"
 	"github.com/sisu-network/tss-lib/tss"
 )
@@ -116,3 +117,65 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestInterpolatePoint(t *testing.T) {
+	curve := "ecdsa"
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(tss.EC(curve).Params().N)
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.EC(curve).Params().N))
+	}
+	vs, shares, err := Create(curve, threshold, secret, ids)
+	assert.NoError(t, err)
+
+	points := make([]*crypto.ECPoint, num)
+	for i, share := range shares {
+		points[i] = crypto.ScalarBaseMult(tss.EC(curve), share.Share)
+	}
+
+	y, err := InterpolatePoint(curve, ids[:threshold+1], points[:threshold+1], big.NewInt(0))
+	assert.NoError(t, err)
+	assert.True(t, y.Equals(vs[0]))
+
+	y, err = InterpolatePoint(curve, ids[1:], points[1:], big.NewInt(0))
+	assert.NoError(t, err)
+	assert.True(t, y.Equals(vs[0]))
+
+	// t+1 points determine the others
+	p4, err := InterpolatePoint(curve, ids[:threshold+1], points[:threshold+1], ids[4])
+	assert.NoError(t, err)
+	assert.True(t, p4.Equals(points[4]))
+
+	// t points do not
+	y, err = InterpolatePoint(curve, ids[:threshold], points[:threshold], big.NewInt(0))
+	assert.NoError(t, err)
+	assert.False(t, y.Equals(vs[0]))
+}
+
+func TestCreateZeroShares(t *testing.T) {
+	curve := "ecdsa"
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.EC(curve).Params().N))
+	}
+
+	vs, shares, err := CreateZeroShares(curve, threshold, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(vs))
+	for _, share := range shares {
+		assert.True(t, share.VerifyZeroShare(curve, threshold, vs))
+	}
+
+	// the shares interpolate to zero
+	secret, err := shares[:threshold+1].ReConstruct(curve)
+	assert.NoError(t, err)
+	assert.Zero(t, secret.Sign())
+
+	bad := *shares[0]
+	bad.Share = new(big.Int).Add(bad.Share, big.NewInt(1))
+	assert.False(t, bad.VerifyZeroShare(curve, threshold, vs))
+}
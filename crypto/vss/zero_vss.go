@@ -0,0 +1,90 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vss
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// CreateZeroShares returns a Feldman sharing of zero with a random polynomial of degree `threshold`.
+// Adding these shares to existing ones re-randomises them without changing the shared secret.
+// The returned Vs holds v1..vt only: v0 would be 0*G, the identity, so a share that verifies against these Vs
+// is known to belong to a polynomial with a zero constant term.
+func CreateZeroShares(curve string, threshold int, indexes []*big.Int) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+	ids, err := CheckIndexes(tss.EC(curve), indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(curve, threshold, zero)
+	v := make(Vs, threshold)
+	for i := 1; i <= threshold; i++ {
+		v[i-1] = crypto.ScalarBaseMult(tss.EC(curve), poly[i])
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(curve, threshold, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyZeroShare checks a share created by CreateZeroShares against its v1..vt.
+func (share *Share) VerifyZeroShare(curve string, threshold int, vs Vs) bool {
+	if share.Threshold != threshold || len(vs) != threshold {
+		return false
+	}
+	v, err := vs.EvaluateZero(curve, share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(tss.EC(curve), share.Share)
+	return sigmaGi != nil && sigmaGi.Equals(v)
+}
+
+// EvaluateZero returns f(id)*G = v1*id + v2*id^2 + .. + vt*id^t for the v1..vt of a zero sharing.
+func (vs Vs) EvaluateZero(curve string, id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("vss EvaluateZero: no commitments")
+	}
+	modQ := common.ModInt(tss.EC(curve).Params().N)
+	var v *crypto.ECPoint
+	t := one
+	for j, vj := range vs {
+		if vj == nil {
+			return nil, fmt.Errorf("vss EvaluateZero: commitment %d is nil", j+1)
+		}
+		t = modQ.Mul(t, id)
+		vjt := vj.SetCurve(tss.EC(curve)).ScalarMult(t)
+		if v == nil {
+			v = vjt
+			continue
+		}
+		var err error
+		if v, err = v.Add(vjt); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,479 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-batchkeygen.proto
+
+package batchkeygen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//
+// Represents a BROADCAST message sent during Round 1 of the ECDSA TSS batch keygen protocol.
+// There is one commitment per key of the batch; the Paillier and NTilde material is shared by every key.
+type BatchKGRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitments [][]byte `protobuf:"bytes,1,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	PaillierN   []byte   `protobuf:"bytes,2,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+	NTilde      []byte   `protobuf:"bytes,3,opt,name=n_tilde,json=nTilde,proto3" json:"n_tilde,omitempty"`
+	H1          []byte   `protobuf:"bytes,4,opt,name=h1,proto3" json:"h1,omitempty"`
+	H2          []byte   `protobuf:"bytes,5,opt,name=h2,proto3" json:"h2,omitempty"`
+	Dlnproof_1  [][]byte `protobuf:"bytes,6,rep,name=dlnproof_1,json=dlnproof1,proto3" json:"dlnproof_1,omitempty"`
+	Dlnproof_2  [][]byte `protobuf:"bytes,7,rep,name=dlnproof_2,json=dlnproof2,proto3" json:"dlnproof_2,omitempty"`
+}
+
+func (x *BatchKGRound1Message) Reset() {
+	*x = BatchKGRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchKGRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchKGRound1Message) ProtoMessage() {}
+
+func (x *BatchKGRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchKGRound1Message.ProtoReflect.Descriptor instead.
+func (*BatchKGRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_batchkeygen_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BatchKGRound1Message) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetPaillierN() []byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetNTilde() []byte {
+	if x != nil {
+		return x.NTilde
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetH1() []byte {
+	if x != nil {
+		return x.H1
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetH2() []byte {
+	if x != nil {
+		return x.H2
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetDlnproof_1() [][]byte {
+	if x != nil {
+		return x.Dlnproof_1
+	}
+	return nil
+}
+
+func (x *BatchKGRound1Message) GetDlnproof_2() [][]byte {
+	if x != nil {
+		return x.Dlnproof_2
+	}
+	return nil
+}
+
+//
+// Represents a P2P message sent to each party during Round 2 of the ECDSA TSS batch keygen protocol.
+// There is one share per key of the batch.
+type BatchKGRound2Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shares [][]byte `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (x *BatchKGRound2Message1) Reset() {
+	*x = BatchKGRound2Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchKGRound2Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchKGRound2Message1) ProtoMessage() {}
+
+func (x *BatchKGRound2Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchKGRound2Message1.ProtoReflect.Descriptor instead.
+func (*BatchKGRound2Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_batchkeygen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchKGRound2Message1) GetShares() [][]byte {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to each party during Round 2 of the ECDSA TSS batch keygen protocol.
+// There is one de-commitment per key of the batch.
+type BatchKGRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitments []*BatchKGRound2Message2_DeCommitment `protobuf:"bytes,1,rep,name=de_commitments,json=deCommitments,proto3" json:"de_commitments,omitempty"`
+}
+
+func (x *BatchKGRound2Message2) Reset() {
+	*x = BatchKGRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchKGRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchKGRound2Message2) ProtoMessage() {}
+
+func (x *BatchKGRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchKGRound2Message2.ProtoReflect.Descriptor instead.
+func (*BatchKGRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_batchkeygen_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchKGRound2Message2) GetDeCommitments() []*BatchKGRound2Message2_DeCommitment {
+	if x != nil {
+		return x.DeCommitments
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to each party during Round 3 of the ECDSA TSS batch keygen protocol.
+type BatchKGRound3Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaillierProof [][]byte `protobuf:"bytes,1,rep,name=paillier_proof,json=paillierProof,proto3" json:"paillier_proof,omitempty"`
+}
+
+func (x *BatchKGRound3Message) Reset() {
+	*x = BatchKGRound3Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchKGRound3Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchKGRound3Message) ProtoMessage() {}
+
+func (x *BatchKGRound3Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchKGRound3Message.ProtoReflect.Descriptor instead.
+func (*BatchKGRound3Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_batchkeygen_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchKGRound3Message) GetPaillierProof() [][]byte {
+	if x != nil {
+		return x.PaillierProof
+	}
+	return nil
+}
+
+type BatchKGRound2Message2_DeCommitment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parts [][]byte `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+}
+
+func (x *BatchKGRound2Message2_DeCommitment) Reset() {
+	*x = BatchKGRound2Message2_DeCommitment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchKGRound2Message2_DeCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchKGRound2Message2_DeCommitment) ProtoMessage() {}
+
+func (x *BatchKGRound2Message2_DeCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_batchkeygen_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchKGRound2Message2_DeCommitment.ProtoReflect.Descriptor instead.
+func (*BatchKGRound2Message2_DeCommitment) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_batchkeygen_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *BatchKGRound2Message2_DeCommitment) GetParts() [][]byte {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+var File_protob_ecdsa_batchkeygen_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_batchkeygen_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x11, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x6b, 0x65, 0x79,
+	0x67, 0x65, 0x6e, 0x22, 0xce, 0x01, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b, 0x47, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x31, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x68, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x32, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x68, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x31, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x64, 0x6c, 0x6e, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x5f, 0x32, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x64, 0x6c, 0x6e, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x32, 0x22, 0x2f, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b, 0x47, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b,
+	0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12,
+	0x5c, 0x0a, 0x0e, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x2e, 0x44, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0d,
+	0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x24, 0x0a,
+	0x0c, 0x44, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x61,
+	0x72, 0x74, 0x73, 0x22, 0x3d, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b, 0x47, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70,
+	0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0d, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x69, 0x73, 0x75, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x74, 0x73,
+	0x73, 0x2d, 0x6c, 0x69, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_batchkeygen_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_batchkeygen_proto_rawDescData = file_protob_ecdsa_batchkeygen_proto_rawDesc
+)
+
+func file_protob_ecdsa_batchkeygen_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_batchkeygen_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_batchkeygen_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_batchkeygen_proto_rawDescData)
+	})
+	return file_protob_ecdsa_batchkeygen_proto_rawDescData
+}
+
+var file_protob_ecdsa_batchkeygen_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_protob_ecdsa_batchkeygen_proto_goTypes = []interface{}{
+	(*BatchKGRound1Message)(nil),               // 0: ecdsa.batchkeygen.BatchKGRound1Message
+	(*BatchKGRound2Message1)(nil),              // 1: ecdsa.batchkeygen.BatchKGRound2Message1
+	(*BatchKGRound2Message2)(nil),              // 2: ecdsa.batchkeygen.BatchKGRound2Message2
+	(*BatchKGRound3Message)(nil),               // 3: ecdsa.batchkeygen.BatchKGRound3Message
+	(*BatchKGRound2Message2_DeCommitment)(nil), // 4: ecdsa.batchkeygen.BatchKGRound2Message2.DeCommitment
+}
+var file_protob_ecdsa_batchkeygen_proto_depIdxs = []int32{
+	4, // 0: ecdsa.batchkeygen.BatchKGRound2Message2.de_commitments:type_name -> ecdsa.batchkeygen.BatchKGRound2Message2.DeCommitment
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_batchkeygen_proto_init() }
+func file_protob_ecdsa_batchkeygen_proto_init() {
+	if File_protob_ecdsa_batchkeygen_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_batchkeygen_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchKGRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_batchkeygen_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchKGRound2Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_batchkeygen_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchKGRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_batchkeygen_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchKGRound3Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_batchkeygen_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchKGRound2Message2_DeCommitment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_batchkeygen_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_batchkeygen_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_batchkeygen_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_batchkeygen_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_batchkeygen_proto = out.File
+	file_protob_ecdsa_batchkeygen_proto_rawDesc = nil
+	file_protob_ecdsa_batchkeygen_proto_goTypes = nil
+	file_protob_ecdsa_batchkeygen_proto_depIdxs = nil
+}
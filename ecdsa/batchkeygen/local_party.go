@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package batchkeygen generates a batch of independent ECDSA keys for the same committee in a single run of the
+// 4-round keygen protocol. Every message carries one commitment, share or de-commitment per key, while the Paillier
+// key, NTilde, h1 and h2 and their proofs are generated, sent and verified once and shared by all keys of the batch.
+//
+// Each key of the output is a regular keygen.LocalPartySaveData and may be used with presign, signing, resharing and
+// refresh like any other key.
+package batchkeygen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sisu-network/tss-lib/common"
+	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		batchSize int
+		temp      localTempData
+		data      []keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		kgRound1Messages,
+		kgRound2Message1s,
+		kgRound2Message2s,
+		kgRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after keygen), indexed by key and then by party
+		preParams     keygen.LocalPreParams
+		KGCs          [][]cmt.HashCommitment
+		vs            []vss.Vs
+		shares        []vss.Shares
+		deCommitPolyG []cmt.HashDeCommitment
+	}
+)
+
+// NewLocalParty returns a party that generates `batchSize` keys in one run.
+// The keys are sent on `end` together, in the same order at every party.
+func NewLocalParty(
+	params *tss.Parameters,
+	batchSize int,
+	out chan<- tss.Message,
+	end chan<- []keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	if batchSize < 1 {
+		panic(errors.New("batchkeygen.NewLocalParty expected a batchSize of at least 1"))
+	}
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		batchSize: batchSize,
+		temp:      localTempData{},
+		data:      make([]keygen.LocalPartySaveData, batchSize),
+		out:       out,
+		end:       end,
+	}
+	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("batchkeygen.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.temp.preParams = optionalPreParams[0]
+	}
+	for k := range p.data {
+		p.data[k] = keygen.NewLocalPartySaveData(partyCount)
+	}
+	// msgs init
+	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.KGCs = make([][]cmt.HashCommitment, batchSize)
+	for k := range p.temp.KGCs {
+		p.temp.KGCs[k] = make([]cmt.HashCommitment, partyCount)
+	}
+	p.temp.vs = make([]vss.Vs, batchSize)
+	p.temp.shares = make([]vss.Shares, batchSize)
+	p.temp.deCommitPolyG = make([]cmt.HashDeCommitment, batchSize)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, p.batchSize, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *BatchKGRound1Message:
+		p.temp.kgRound1Messages[fromPIdx] = msg
+	case *BatchKGRound2Message1:
+		p.temp.kgRound2Message1s[fromPIdx] = msg
+	case *BatchKGRound2Message2:
+		p.temp.kgRound2Message2s[fromPIdx] = msg
+	case *BatchKGRound3Message:
+		p.temp.kgRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
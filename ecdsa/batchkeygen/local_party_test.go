@@ -0,0 +1,143 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	. "github.com/sisu-network/tss-lib/ecdsa/batchkeygen"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/ecdsa/recovery"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+	testBatchSize    = 3
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// use the fixture pre-params; fresh safe primes would take minutes to generate
+	fixtures, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan []keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), testThreshold)
+		P := NewLocalParty(params, testBatchSize, outCh, endCh, fixtures[j].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+	}
+	// start every party before routing so that no message arrives ahead of its recipient's first round
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	batches := make([][]keygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case saves := <-endCh:
+			if !assert.Len(t, saves, testBatchSize) {
+				return
+			}
+			index, err := saves[0].OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			batches[index] = saves
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+				goto verify
+			}
+		}
+	}
+
+verify:
+	seen := make(map[string]struct{}, testBatchSize)
+	for k := 0; k < testBatchSize; k++ {
+		keys := make([]keygen.LocalPartySaveData, len(pIDs))
+		pubs := make([]keygen.LocalPartyPublicData, len(pIDs))
+		for j := range pIDs {
+			keys[j] = batches[j][k]
+			assert.NoErrorf(t, keys[j].Validate(), "key %d of party %d should validate", k, j)
+			pubs[j] = keys[j].PublicData()
+		}
+		assert.NoErrorf(t, keygen.VerifyCommittee(pubs), "key %d", k)
+
+		sk, err := recovery.ReconstructKey(testThreshold, keys)
+		if assert.NoErrorf(t, err, "key %d should reconstruct", k) {
+			assert.True(t, sk.PublicKey.X.Cmp(keys[0].ECDSAPub.X()) == 0 && sk.PublicKey.Y.Cmp(keys[0].ECDSAPub.Y()) == 0)
+		}
+		id := keys[0].ECDSAPub.X().String()
+		_, dup := seen[id]
+		assert.Falsef(t, dup, "key %d should differ from the other keys in the batch", k)
+		seen[id] = struct{}{}
+	}
+}
+
+func TestKeyErrors(t *testing.T) {
+	_, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	keyErr := &KeyError{Index: 2, Culprits: pIDs[1:2], Err: errors.New("vss verify failed")}
+	tssErr := tss.NewError(multierror.Append(nil, keyErr), TaskName, 3, pIDs[0], pIDs[1])
+	keyErrs := KeyErrors(tssErr)
+	if assert.Len(t, keyErrs, 1) {
+		assert.Equal(t, 2, keyErrs[0].Index)
+		assert.Equal(t, pIDs[1], keyErrs[0].Culprits[0])
+	}
+	assert.Empty(t, KeyErrors(tss.NewError(errors.New("other"), TaskName, 3, pIDs[0])))
+}
@@ -0,0 +1,217 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen
+
+import (
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/dlnp"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-batchkeygen.pb.go
+// The number of items in each message is checked against the batch size by the rounds.
+
+var (
+	// Ensure that batch keygen messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*BatchKGRound1Message)(nil),
+		(*BatchKGRound2Message1)(nil),
+		(*BatchKGRound2Message2)(nil),
+		(*BatchKGRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewBatchKGRound1Message(
+	from *tss.PartyID,
+	cts []cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnp.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	content := &BatchKGRound1Message{
+		Commitments: common.BigIntsToBytes(cts),
+		PaillierN:   paillierPK.N.Bytes(),
+		NTilde:      nTildeI.Bytes(),
+		H1:          h1I.Bytes(),
+		H2:          h2I.Bytes(),
+		Dlnproof_1:  dlnProof1Bz,
+		Dlnproof_2:  dlnProof2Bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *BatchKGRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetCommitments()) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2))
+}
+
+func (m *BatchKGRound1Message) UnmarshalCommitments() []cmt.HashCommitment {
+	return common.ByteSlicesToBigInts(m.GetCommitments())
+}
+
+func (m *BatchKGRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *BatchKGRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *BatchKGRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *BatchKGRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *BatchKGRound1Message) UnmarshalDLNProof1() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_1())
+}
+
+func (m *BatchKGRound1Message) UnmarshalDLNProof2() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewBatchKGRound2Message1(
+	to, from *tss.PartyID,
+	shares []*vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	sharesBzs := make([][]byte, len(shares))
+	for k, share := range shares {
+		sharesBzs[k] = share.Share.Bytes()
+	}
+	content := &BatchKGRound2Message1{
+		Shares: sharesBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *BatchKGRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetShares())
+}
+
+func (m *BatchKGRound2Message1) UnmarshalShares() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetShares())
+}
+
+// ----- //
+
+func NewBatchKGRound2Message2(
+	from *tss.PartyID,
+	deCommitments []cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcs := make([]*BatchKGRound2Message2_DeCommitment, len(deCommitments))
+	for k, deCommitment := range deCommitments {
+		dcs[k] = &BatchKGRound2Message2_DeCommitment{
+			Parts: common.BigIntsToBytes(deCommitment),
+		}
+	}
+	content := &BatchKGRound2Message2{
+		DeCommitments: dcs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *BatchKGRound2Message2) ValidateBasic() bool {
+	if m == nil || len(m.GetDeCommitments()) == 0 {
+		return false
+	}
+	for _, dc := range m.GetDeCommitments() {
+		if !common.NonEmptyMultiBytes(dc.GetParts()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *BatchKGRound2Message2) UnmarshalDeCommitments() []cmt.HashDeCommitment {
+	dcs := make([]cmt.HashDeCommitment, len(m.GetDeCommitments()))
+	for k, dc := range m.GetDeCommitments() {
+		dcs[k] = cmt.NewHashDeCommitmentFromBytes(dc.GetParts())
+	}
+	return dcs
+}
+
+// ----- //
+
+func NewBatchKGRound3Message(
+	from *tss.PartyID,
+	proof paillier.Proof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	pfBzs := make([][]byte, len(proof))
+	for i := range pfBzs {
+		if proof[i] == nil {
+			continue
+		}
+		pfBzs[i] = proof[i].Bytes()
+	}
+	content := &BatchKGRound3Message{
+		PaillierProof: pfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *BatchKGRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+}
+
+func (m *BatchKGRound3Message) UnmarshalProofInts() paillier.Proof {
+	var pf paillier.Proof
+	proofBzs := m.GetPaillierProof()
+	for i := range pf {
+		pf[i] = new(big.Int).SetBytes(proofBzs[i])
+	}
+	return pf
+}
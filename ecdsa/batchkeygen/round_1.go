@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	cmts "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/dlnp"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+var (
+	zero = big.NewInt(0)
+)
+
+// round 1 represents round 1 of the keygen part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018), run for every key of the batch
+func newRound1(params *tss.Parameters, batchSize int, save []keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- []keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, batchSize, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	ids := round.Parties().IDs().Keys()
+
+	// 1-3. for each key: calculate "partial" key share ui, compute the vss shares and make commitment -> (C, D)
+	cmtCs := make([]cmts.HashCommitment, round.batchSize)
+	for k := 0; k < round.batchSize; k++ {
+		ui := common.GetRandomPositiveInt(round.EC().Params().N)
+		vs, shares, err := vss.Create(round.Curve(), round.Threshold(), ui, ids)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		// security: the original u_i may be discarded
+		ui.SetInt64(0)
+
+		pGFlat, err := crypto.FlattenECPoints(vs)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		cmt := cmts.NewHashCommitment(pGFlat...)
+		cmtCs[k] = cmt.C
+		round.temp.vs[k] = vs
+		round.temp.shares[k] = shares
+		round.temp.deCommitPolyG[k] = cmt.D
+	}
+
+	// 4. generate Paillier public key E_i, private key and proof
+	// 5-7. generate safe primes for ZKPs used later on
+	// 9-11. compute ntilde, h1, h2 (uses safe primes)
+	// use the pre-params if they were provided to the LocalParty constructor; they are shared by every key
+	var preParams *keygen.LocalPreParams
+	if round.temp.preParams.ValidateWithProof() {
+		preParams = &round.temp.preParams
+	} else {
+		var err error
+		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout(), 3)
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.temp.preParams = *preParams
+	}
+
+	// generate the dlnproofs for keygen
+	h1i, h2i, alpha, beta, p, q, NTildei :=
+		preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei
+	dlnProof1 := dlnp.NewProof(h1i, h2i, alpha, p, q, NTildei)
+	dlnProof2 := dlnp.NewProof(h2i, h1i, beta, p, q, NTildei)
+
+	// for this P: SAVE the shareID, pre-params and our own Paillier and NTilde material for every key
+	for k := range round.save {
+		round.save[k].Ks = ids
+		round.save[k].ShareID = ids[i]
+		round.save[k].LocalPreParams = *preParams
+		round.save[k].NTildej[i] = preParams.NTildei
+		round.save[k].H1j[i], round.save[k].H2j[i] = preParams.H1i, preParams.H2i
+		round.save[k].PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+	}
+
+	// BROADCAST commitments, paillier pk + proof; round 1 message
+	{
+		msg, err := NewBatchKGRound1Message(
+			round.PartyID(), cmtCs, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.kgRound1Messages[i] = msg
+		round.out <- msg
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*BatchKGRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.kgRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// vss check is in round 2
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
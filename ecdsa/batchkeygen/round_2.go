@@ -0,0 +1,166 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 6. verify dln proofs, store r1 message pieces, ensure uniqueness of h1j, h2j
+	h1H2Map := make(map[string]struct{}, len(round.temp.kgRound1Messages)*2)
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.kgRound1Messages {
+		r1msg := msg.Content().(*BatchKGRound1Message)
+		H1j, H2j, NTildej, paillierPubKeyj :=
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+
+		if len(r1msg.GetCommitments()) != round.batchSize {
+			return round.WrapError(fmt.Errorf("expected %d commitments but got %d", round.batchSize, len(r1msg.GetCommitments())), msg.GetFrom())
+		}
+
+		if paillierPubKeyj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+
+		wg.Add(2)
+		go func(j int, msg tss.ParsedMessage, r1msg *BatchKGRound1Message, H1j, H2j, NTildej *big.Int) {
+			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+				dlnProof1FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+		go func(j int, msg tss.ParsedMessage, r1msg *BatchKGRound1Message, H1j, H2j, NTildej *big.Int) {
+			if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+				dlnProof2FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+	// save NTilde_j, h1_j, h2_j, ... for every key
+	for j, msg := range round.temp.kgRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*BatchKGRound1Message)
+		paillierPK, H1j, H2j, NTildej, KGCs :=
+			r1msg.UnmarshalPaillierPK(),
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalCommitments()
+		for k := range round.save {
+			round.save[k].PaillierPKs[j] = paillierPK // used in round 4
+			round.save[k].NTildej[j] = NTildej
+			round.save[k].H1j[j], round.save[k].H2j[j] = H1j, H2j
+			round.temp.KGCs[k][j] = KGCs[k]
+		}
+	}
+
+	// 5. p2p send the shares ij of every key to Pj
+	for j, Pj := range round.Parties().IDs() {
+		shares := make([]*vss.Share, round.batchSize)
+		for k := range shares {
+			shares[k] = round.temp.shares[k][j]
+		}
+		r2msg1 := NewBatchKGRound2Message1(Pj, round.PartyID(), shares)
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.kgRound2Message1s[j] = r2msg1
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// 7. BROADCAST de-commitments of Shamir poly*G for every key
+	r2msg2 := NewBatchKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	round.temp.kgRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*BatchKGRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*BatchKGRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	// guard - VERIFY de-commit for all Pj
+	for j, msg := range round.temp.kgRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		msg2 := round.temp.kgRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,200 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// every Pj must have sent a share and a de-commitment for each key
+	for j, Pj := range Ps {
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*BatchKGRound2Message1)
+		r2msg2 := round.temp.kgRound2Message2s[j].Content().(*BatchKGRound2Message2)
+		if len(r2msg1.GetShares()) != round.batchSize || len(r2msg2.GetDeCommitments()) != round.batchSize {
+			return round.WrapError(fmt.Errorf("expected %d shares and de-commitments", round.batchSize), Pj)
+		}
+	}
+
+	// 4-11. de-commit and verify the shares of every key (concurrent per Pj)
+	type vssOut struct {
+		unWrappedErrs []error // indexed by key
+		pjVs          []vss.Vs
+	}
+	chs := make([]chan vssOut, len(Ps))
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		chs[j] = make(chan vssOut)
+		go func(j int, ch chan<- vssOut) {
+			out := vssOut{make([]error, round.batchSize), make([]vss.Vs, round.batchSize)}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*BatchKGRound2Message1)
+			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*BatchKGRound2Message2)
+			shares, KGDs := r2msg1.UnmarshalShares(), r2msg2.UnmarshalDeCommitments()
+			for k := 0; k < round.batchSize; k++ {
+				cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.KGCs[k][j], D: KGDs[k]}
+				ok, flatPolyGs := cmtDeCmt.DeCommit()
+				if !ok || flatPolyGs == nil {
+					out.unWrappedErrs[k] = errors.New("de-commitment verify failed")
+					continue
+				}
+				PjVs, err := crypto.UnFlattenECPoints(round.EC(), flatPolyGs)
+				if err != nil {
+					out.unWrappedErrs[k] = err
+					continue
+				}
+				PjShare := vss.Share{
+					Threshold: round.Threshold(),
+					ID:        round.PartyID().KeyInt(),
+					Share:     shares[k],
+				}
+				if ok = PjShare.Verify(round.Curve(), round.Threshold(), PjVs); !ok {
+					out.unWrappedErrs[k] = errors.New("vss verify failed")
+					continue
+				}
+				out.pjVs[k] = PjVs
+			}
+			ch <- out
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines) and collect the culprits of each key
+	vssResults := make([]vssOut, len(Ps))
+	keyErrs := make([]*KeyError, round.batchSize)
+	for j, Pj := range Ps {
+		if j == PIdx {
+			continue
+		}
+		vssResults[j] = <-chs[j]
+		for k, err := range vssResults[j].unWrappedErrs {
+			if err == nil {
+				continue
+			}
+			if keyErrs[k] == nil {
+				keyErrs[k] = &KeyError{Index: k, Err: err}
+			}
+			keyErrs[k].Culprits = append(keyErrs[k].Culprits, Pj)
+		}
+	}
+	if failed := nonNilKeyErrors(keyErrs); len(failed) > 0 {
+		return round.wrapKeyErrors(failed)
+	}
+
+	// 1,9. calculate xi; 2-3,10-11. Vc; 12-16. Xj; 17. the ECDSA public key `y` for each key
+	modQ := common.ModInt(round.EC().Params().N)
+	for k := 0; k < round.batchSize; k++ {
+		xi := new(big.Int).Set(round.temp.shares[k][PIdx].Share)
+		Vc := make(vss.Vs, round.Threshold()+1)
+		copy(Vc, round.temp.vs[k]) // ours
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*BatchKGRound2Message1)
+			xi = modQ.Add(xi, r2msg1.UnmarshalShares()[k])
+			PjVs := vssResults[j].pjVs[k]
+			for c := 0; c <= round.Threshold(); c++ {
+				var err error
+				if Vc[c], err = Vc[c].Add(PjVs[c]); err != nil {
+					return round.wrapKeyErrors([]*KeyError{{k, []*tss.PartyID{Pj},
+						errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve")}})
+				}
+			}
+		}
+		round.save[k].Xi = xi
+
+		bigXj := round.save[k].BigXj
+		for j, Pj := range Ps {
+			kj := Pj.KeyInt()
+			BigXj := Vc[0]
+			z := new(big.Int).SetInt64(int64(1))
+			for c := 1; c <= round.Threshold(); c++ {
+				var err error
+				z = modQ.Mul(z, kj)
+				if BigXj, err = BigXj.Add(Vc[c].ScalarMult(z)); err != nil {
+					return round.wrapKeyErrors([]*KeyError{{k, []*tss.PartyID{Pj},
+						errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve")}})
+				}
+			}
+			bigXj[j] = BigXj
+		}
+
+		ecdsaPubKey, err := crypto.NewECPoint(round.EC(), Vc[0].X(), Vc[0].Y())
+		if err != nil {
+			return round.wrapKeyErrors([]*KeyError{{k, nil, errors2.Wrapf(err, "public key is not on the curve")}})
+		}
+		round.save[k].ECDSAPub = ecdsaPubKey
+		common.Logger.Debugf("%s public key %d: %x", round.PartyID(), k, ecdsaPubKey)
+	}
+
+	// BROADCAST paillier proof for Pi; the Paillier key is shared so it is proven once, bound to the first key
+	ki := round.PartyID().KeyInt()
+	proof := round.temp.preParams.PaillierSK.Proof(ki, round.save[0].ECDSAPub)
+	r3msg := NewBatchKGRound3Message(round.PartyID(), proof)
+	round.temp.kgRound3Messages[PIdx] = r3msg
+	round.out <- r3msg
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*BatchKGRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.kgRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// proof check is in round 4
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
+
+// ----- //
+
+func nonNilKeyErrors(keyErrs []*KeyError) []*KeyError {
+	failed := make([]*KeyError, 0, len(keyErrs))
+	for _, keyErr := range keyErrs {
+		if keyErr != nil {
+			failed = append(failed, keyErr)
+		}
+	}
+	return failed
+}
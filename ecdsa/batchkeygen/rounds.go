@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package batchkeygen
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	TaskName = "ecdsa-batch-keygen"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		batchSize int
+		save      []keygen.LocalPartySaveData
+		temp      *localTempData
+		out       chan<- tss.Message
+		end       chan<- []keygen.LocalPartySaveData
+		ok        []bool // `ok` tracks parties which have been verified by Update()
+		started   bool
+		number    int
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+	round4 struct {
+		*round3
+	}
+
+	// KeyError reports why the key at Index of the batch failed and which parties are to blame for it.
+	// The cause of a *tss.Error returned by a batch party is a *multierror.Error of KeyErrors when a failure is
+	// specific to some keys of the batch; use KeyErrors to retrieve them.
+	KeyError struct {
+		Index    int
+		Culprits []*tss.PartyID
+		Err      error
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*round2)(nil)
+	_ tss.Round = (*round3)(nil)
+	_ tss.Round = (*round4)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// wrapKeyErrors returns a *tss.Error whose cause lists every KeyError and whose culprits are all of the parties
+// blamed for any of the keys.
+func (round *base) wrapKeyErrors(keyErrs []*KeyError) *tss.Error {
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(round.ok))
+	blamed := make(map[int]struct{}, len(round.ok))
+	for _, keyErr := range keyErrs {
+		multiErr = multierror.Append(multiErr, keyErr)
+		for _, Pj := range keyErr.Culprits {
+			if _, found := blamed[Pj.Index]; found {
+				continue
+			}
+			blamed[Pj.Index] = struct{}{}
+			culprits = append(culprits, Pj)
+		}
+	}
+	return round.WrapError(multiErr, culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// ----- //
+
+func (err *KeyError) Error() string {
+	return fmt.Sprintf("key %d, culprits %s: %v", err.Index, err.Culprits, err.Err)
+}
+
+func (err *KeyError) Unwrap() error { return err.Err }
+
+// KeyErrors returns the per-key failures carried by an error returned from a batch party, or nil if there are none.
+func KeyErrors(err error) []*KeyError {
+	tssErr, ok := err.(*tss.Error)
+	if !ok || tssErr == nil {
+		return nil
+	}
+	multiErr, ok := tssErr.Cause().(*multierror.Error)
+	if !ok {
+		return nil
+	}
+	keyErrs := make([]*KeyError, 0, len(multiErr.Errors))
+	for _, e := range multiErr.Errors {
+		if keyErr, ok := e.(*KeyError); ok {
+			keyErrs = append(keyErrs, keyErr)
+		}
+	}
+	return keyErrs
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+)
+
+// AddEnrolledParty extends the save data `key` of a party that did not help with an enrollment with the new party,
+// given the PublicData() of the new party's save data and `proofs`, the round 1 broadcast of the new party as relayed
+// by the new party or a helper. The public share of the new party must lie on the key polynomial and the new party
+// must agree with `key` on every other party. Its Paillier key and NTilde, h1, h2 must be those of `proofs`, whose dln
+// and Paillier proofs are verified again here, so a tampered public data is rejected.
+func AddEnrolledParty(
+	key keygen.LocalPartySaveData,
+	enrolled keygen.LocalPartyPublicData,
+	proofs *EnrollmentRound1Message2,
+) (keygen.LocalPartySaveData, error) {
+	if err := enrolled.Validate(); err != nil {
+		return key, fmt.Errorf("AddEnrolledParty: %v", err)
+	}
+	if len(enrolled.Ks) != len(key.Ks)+1 {
+		return key, fmt.Errorf("AddEnrolledParty: expected %d parties in the public data but got %d",
+			len(key.Ks)+1, len(enrolled.Ks))
+	}
+	if !enrolled.ECDSAPub.Equals(key.ECDSAPub) {
+		return key, errors.New("AddEnrolledParty: the public data belongs to a different key")
+	}
+	index := make(map[string]int, len(enrolled.Ks))
+	for j, kj := range enrolled.Ks {
+		index[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newIdx, ok := index[hex.EncodeToString(enrolled.ShareID.Bytes())]
+	if !ok {
+		return key, errors.New("AddEnrolledParty: ShareID was not found in Ks")
+	}
+	for j, kj := range key.Ks {
+		e, ok := index[hex.EncodeToString(kj.Bytes())]
+		if !ok || e == newIdx {
+			return key, fmt.Errorf("AddEnrolledParty: Ks[%d] is missing from the public data", j)
+		}
+		if !enrolled.BigXj[e].Equals(key.BigXj[j]) {
+			return key, fmt.Errorf("AddEnrolledParty: BigXj differs for Ks[%d]", j)
+		}
+		if enrolled.PaillierPKs[e].N.Cmp(key.PaillierPKs[j].N) != 0 || enrolled.NTildej[e].Cmp(key.NTildej[j]) != 0 ||
+			enrolled.H1j[e].Cmp(key.H1j[j]) != 0 || enrolled.H2j[e].Cmp(key.H2j[j]) != 0 {
+			return key, fmt.Errorf("AddEnrolledParty: the pre-params differ for Ks[%d]", j)
+		}
+	}
+	paillierPK, NTilde, H1, H2, err := verifyPreParams(proofs, enrolled.ShareID, &key)
+	if err != nil {
+		return key, fmt.Errorf("AddEnrolledParty: %v", err)
+	}
+	if enrolled.PaillierPKs[newIdx].N.Cmp(paillierPK.N) != 0 || enrolled.NTildej[newIdx].Cmp(NTilde) != 0 ||
+		enrolled.H1j[newIdx].Cmp(H1) != 0 || enrolled.H2j[newIdx].Cmp(H2) != 0 {
+		return key, errors.New("AddEnrolledParty: the pre-params of the new party differ from the proved ones")
+	}
+	before, err := key.PublicData().Threshold()
+	if err != nil {
+		return key, fmt.Errorf("AddEnrolledParty: %v", err)
+	}
+	if after, _ := enrolled.Threshold(); after != before {
+		return key, fmt.Errorf("AddEnrolledParty: the threshold of the public data is %d, not %d", after, before)
+	}
+	return withParty(key, enrolled.ShareID, enrolled.BigXj[newIdx], paillierPK, NTilde, H1, H2), nil
+}
+
+// ----- //
+
+// withParty returns a copy of `key` with the party of share ID `k`, public share `bigX` and the given pre-params appended
+func withParty(
+	key keygen.LocalPartySaveData,
+	k *big.Int,
+	bigX *crypto.ECPoint,
+	paillierPK *paillier.PublicKey,
+	NTilde, H1, H2 *big.Int,
+) keygen.LocalPartySaveData {
+	n := len(key.Ks)
+	save := keygen.NewLocalPartySaveData(n + 1)
+	save.LocalPreParams = key.LocalPreParams
+	save.LocalSecrets = key.LocalSecrets
+	save.ECDSAPub = key.ECDSAPub
+	save.Epoch = key.Epoch
+	copy(save.Ks, key.Ks)
+	copy(save.NTildej, key.NTildej)
+	copy(save.H1j, key.H1j)
+	copy(save.H2j, key.H2j)
+	copy(save.BigXj, key.BigXj)
+	copy(save.PaillierPKs, key.PaillierPKs)
+	save.Ks[n], save.BigXj[n], save.PaillierPKs[n] = k, bigX, paillierPK
+	save.NTildej[n], save.H1j[n], save.H2j[n] = NTilde, H1, H2
+	return save
+}
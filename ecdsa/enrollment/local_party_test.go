@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment_test
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sisu-network/tss-lib/common"
+	. "github.com/sisu-network/tss-lib/ecdsa/enrollment"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/ecdsa/recovery"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EEnrollParty(t *testing.T) {
+	setUp("info")
+
+	// the key is held by all but the last fixture party, whose pre-params go to the new party; a fresh Paillier key
+	// would take minutes to generate
+	fixtures, fixturePIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldPIDs := fixturePIDs[:testParticipants-1]
+	oldKeys := make([]keygen.LocalPartySaveData, len(oldPIDs))
+	for j := range oldKeys {
+		oldKeys[j] = keygen.BuildLocalSaveDataSubset(fixtures[j], oldPIDs)
+	}
+	preParams := fixtures[testParticipants-1].LocalPreParams
+	oldSK, err := recovery.ReconstructKey(testThreshold, oldKeys)
+	assert.NoError(t, err)
+
+	// PHASE: the first t+1 parties of the key enroll one new party
+	helperKeys := oldKeys[:testThreshold+1]
+	added := tss.GenerateTestPartyIDs(1, len(oldPIDs))[0]
+	pIDs := tss.SortPartyIDs(append(append(tss.UnSortedPartyIDs{}, oldPIDs[:testThreshold+1]...), added))
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for _, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), testThreshold)
+		if pID.KeyInt().Cmp(added.KeyInt()) == 0 {
+			parties = append(parties, NewEnrolleeParty(params, helperKeys[0].PublicData(), preParams, outCh, endCh).(*LocalParty))
+			continue
+		}
+		parties = append(parties, NewLocalParty(params, helperKeys[oldPIDs.IndexOf(pID.KeyInt())], outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the parties that do not help check the pre-params of the new party with its round 1 broadcast
+	var proofs *EnrollmentRound1Message2
+	newKeys := make([]keygen.LocalPartySaveData, 0, len(pIDs))
+	for len(newKeys) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			if r1msg2, ok := msg.(tss.ParsedMessage).Content().(*EnrollmentRound1Message2); ok {
+				proofs = r1msg2
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			newKeys = append(newKeys, save)
+		}
+	}
+
+	// PHASE: verify; the helpers keep their shares and the new party holds a share of the same key
+	var enrolled keygen.LocalPartySaveData
+	pubs := make([]keygen.LocalPartyPublicData, 0, len(oldPIDs)+1)
+	for _, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.Len(t, key.Ks, len(oldPIDs)+1)
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[0].ECDSAPub), "the public key must not change")
+		pubs = append(pubs, key.PublicData())
+		if key.ShareID.Cmp(added.KeyInt()) == 0 {
+			enrolled = key
+			continue
+		}
+		j := oldPIDs.IndexOf(key.ShareID)
+		assert.Equal(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "the share of a helper must not change")
+		assert.Equal(t, oldKeys[j].Epoch, key.Epoch)
+	}
+	if !assert.NotNil(t, enrolled.Xi, "the new party must output its save data") {
+		return
+	}
+	assert.Equal(t, 0, enrolled.PaillierSK.N.Cmp(preParams.PaillierSK.N), "the new party must keep its pre-params")
+	assert.Equal(t, oldKeys[0].Epoch, enrolled.Epoch)
+
+	// the other parties of the key add the new party offline
+	for _, key := range oldKeys[testThreshold+1:] {
+		extended, err := AddEnrolledParty(key, enrolled.PublicData(), proofs)
+		assert.NoError(t, err)
+		assert.NoError(t, extended.Validate())
+		assert.Equal(t, 0, extended.Xi.Cmp(key.Xi), "the share of a party must not change")
+		pubs = append(pubs, extended.PublicData())
+	}
+	assert.NoError(t, keygen.VerifyCommittee(pubs))
+
+	// the new share combines with the unchanged shares of t parties that did not help
+	newSK, err := recovery.ReconstructKey(testThreshold,
+		append([]keygen.LocalPartySaveData{enrolled}, oldKeys[len(oldKeys)-testThreshold:]...))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldSK.D.Cmp(newSK.D))
+
+	// a public share of the new party that is off the key polynomial is rejected
+	tampered := enrolled.PublicData()
+	tampered.BigXj = append(tampered.BigXj[:0:0], tampered.BigXj...)
+	tampered.BigXj[len(tampered.BigXj)-1] = oldKeys[0].BigXj[0]
+	_, err = AddEnrolledParty(oldKeys[len(oldKeys)-1], tampered, proofs)
+	assert.Error(t, err)
+
+	// so are pre-params of the new party other than the proved ones, and proofs of other pre-params
+	newIdx := len(enrolled.Ks) - 1
+	otherN := oldKeys[0].PaillierPKs[0]
+	tampered = enrolled.PublicData()
+	tampered.PaillierPKs = append(tampered.PaillierPKs[:0:0], tampered.PaillierPKs...)
+	tampered.PaillierPKs[newIdx] = otherN
+	_, err = AddEnrolledParty(oldKeys[len(oldKeys)-1], tampered, proofs)
+	assert.Error(t, err, "a Paillier key other than the proved one must be rejected")
+
+	tampered = enrolled.PublicData()
+	tampered.H1j = append(tampered.H1j[:0:0], tampered.H1j...)
+	tampered.H2j = append(tampered.H2j[:0:0], tampered.H2j...)
+	tampered.H1j[newIdx], tampered.H2j[newIdx] = tampered.H2j[newIdx], tampered.H1j[newIdx]
+	_, err = AddEnrolledParty(oldKeys[len(oldKeys)-1], tampered, proofs)
+	assert.Error(t, err, "h1 and h2 other than the proved ones must be rejected")
+
+	forged := proto.Clone(proofs).(*EnrollmentRound1Message2)
+	forged.PaillierN = otherN.N.Bytes()
+	tampered = enrolled.PublicData()
+	tampered.PaillierPKs = append(tampered.PaillierPKs[:0:0], tampered.PaillierPKs...)
+	tampered.PaillierPKs[newIdx] = otherN
+	_, err = AddEnrolledParty(oldKeys[len(oldKeys)-1], tampered, forged)
+	assert.Error(t, err, "a Paillier proof for another modulus must be rejected")
+
+	_, err = AddEnrolledParty(oldKeys[len(oldKeys)-1], enrolled.PublicData(), nil)
+	assert.Error(t, err, "the pre-params of the new party must be proved")
+}
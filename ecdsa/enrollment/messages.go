@@ -0,0 +1,192 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto/dlnp"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-enrollment.pb.go
+
+var (
+	// Ensure that enrollment messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*EnrollmentRound1Message1)(nil),
+		(*EnrollmentRound1Message2)(nil),
+		(*EnrollmentRound2Message)(nil),
+		(*EnrollmentRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewEnrollmentRound1Message1(
+	to, from *tss.PartyID,
+	epoch uint64,
+	mask *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &EnrollmentRound1Message1{
+		Mask:  mask.Bytes(),
+		Epoch: epoch,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetMask())
+}
+
+func (m *EnrollmentRound1Message1) UnmarshalMask() *big.Int {
+	return new(big.Int).SetBytes(m.GetMask())
+}
+
+// ----- //
+
+// NewEnrollmentRound1Message2 builds the round 1 broadcast of the new party: its Paillier key and NTilde along with
+// the proofs that they are well-formed.
+func NewEnrollmentRound1Message2(
+	from *tss.PartyID,
+	preParams *keygen.LocalPreParams,
+	dlnProof1, dlnProof2 *dlnp.Proof,
+	paillierProof paillier.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	pfBzs := make([][]byte, len(paillierProof))
+	for i := range pfBzs {
+		if paillierProof[i] == nil {
+			continue
+		}
+		pfBzs[i] = paillierProof[i].Bytes()
+	}
+	content := &EnrollmentRound1Message2{
+		PaillierN:     preParams.PaillierSK.N.Bytes(),
+		NTilde:        preParams.NTildei.Bytes(),
+		H1:            preParams.H1i.Bytes(),
+		H2:            preParams.H2i.Bytes(),
+		Dlnproof_1:    dlnProof1Bz,
+		Dlnproof_2:    dlnProof2Bz,
+		PaillierProof: pfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *EnrollmentRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalDLNProof1() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_1())
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalDLNProof2() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_2())
+}
+
+func (m *EnrollmentRound1Message2) UnmarshalPaillierProof() paillier.Proof {
+	var pf paillier.Proof
+	proofBzs := m.GetPaillierProof()
+	for i := range pf {
+		pf[i] = new(big.Int).SetBytes(proofBzs[i])
+	}
+	return pf
+}
+
+// ----- //
+
+func NewEnrollmentRound2Message(
+	to, from *tss.PartyID,
+	epoch uint64,
+	share *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &EnrollmentRound2Message{
+		Share: share.Bytes(),
+		Epoch: epoch,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *EnrollmentRound2Message) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetShare())
+}
+
+// ----- //
+
+func NewEnrollmentRound3Message(
+	from *tss.PartyID,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &EnrollmentRound3Message{}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound3Message) ValidateBasic() bool {
+	return m != nil
+}
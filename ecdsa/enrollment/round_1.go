@@ -0,0 +1,159 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto/dlnp"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. the parties are t+1 parties of the key and the new party
+	if round.PartyCount() != round.Threshold()+2 {
+		return round.WrapError(fmt.Errorf("enrollment needs t+1=%d helpers and the new party but got %d parties",
+			round.Threshold()+1, round.PartyCount()), Pi)
+	}
+	newIdx, err := findNewParty(round.input.Ks, round.Parties().IDs())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.newIdx = newIdx
+	if round.temp.enrollee != (newIdx == i) {
+		return round.WrapError(errors.New("the save data does not match the role of this party"), Pi)
+	}
+	if !round.temp.enrollee {
+		if err := round.input.Validate(); err != nil {
+			return round.WrapError(err, Pi)
+		}
+		if round.input.ShareID.Cmp(Pi.KeyInt()) != 0 {
+			return round.WrapError(errors.New("the save data does not belong to this party"), Pi)
+		}
+	}
+	if round.input.ECDSAPub == nil || round.input.ECDSAPub.Curve() != round.EC() {
+		return round.WrapError(fmt.Errorf("the key is not on the %s curve set in the parameters", round.Curve()), Pi)
+	}
+	if t, err := round.input.PublicData().Threshold(); err != nil || t != round.Threshold() {
+		return round.WrapError(fmt.Errorf("the threshold of the key does not match t=%d", round.Threshold()), Pi)
+	}
+
+	// 2. the public share of the new party is interpolated from those of the helpers
+	helpers := keygen.BuildLocalSaveDataSubset(*round.input, round.helpers())
+	newKey := round.Parties().IDs()[newIdx].KeyInt()
+	if round.temp.newBigX, err = vss.InterpolatePoint(round.Curve(), helpers.Ks, helpers.BigXj, newKey); err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 3. the new party BROADCASTS its pre-params with the proofs that they are well-formed; round 1 message 2
+	if round.temp.enrollee {
+		preParams := round.input.LocalPreParams
+		dlnProof1 := dlnp.NewProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei)
+		dlnProof2 := dlnp.NewProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei)
+		paillierPf := preParams.PaillierSK.Proof(Pi.KeyInt(), round.input.ECDSAPub)
+		r1msg2, err := NewEnrollmentRound1Message2(Pi, &preParams, dlnProof1, dlnProof2, paillierPf)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.enRound1Message2s[i] = r1msg2
+		round.out <- r1msg2
+		round.allOK()
+		return nil
+	}
+	round.ok[i] = true
+
+	// 4. p2p send a random mask to each other helper; the masks cancel out in the sum of the shares of round 2
+	q := round.EC().Params().N
+	for j, Pj := range round.Parties().IDs() {
+		if j == i || j == newIdx {
+			continue
+		}
+		round.temp.masks[j] = common.GetRandomPositiveInt(q)
+		round.out <- NewEnrollmentRound1Message1(Pj, Pi, round.input.Epoch, round.temp.masks[j])
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EnrollmentRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*EnrollmentRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.enRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		// a helper waits for the masks of the other helpers and the pre-params of the new party
+		if j == round.temp.newIdx {
+			msg = round.temp.enRound1Message2s[j]
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// findNewParty returns the index of the one party that does not hold a share of the key in `ks`.
+func findNewParty(ks []*big.Int, parties tss.SortedPartyIDs) (int, error) {
+	keys := make(map[string]struct{}, len(ks))
+	for _, kj := range ks {
+		if kj == nil {
+			return -1, errors.New("the save data has a missing share ID in Ks")
+		}
+		keys[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	newIdx := -1
+	for j, Pj := range parties {
+		if _, ok := keys[hex.EncodeToString(Pj.Key)]; ok {
+			continue
+		}
+		if newIdx >= 0 {
+			return -1, fmt.Errorf("parties %s and %s both do not hold a share of this key", parties[newIdx], Pj)
+		}
+		newIdx = j
+	}
+	if newIdx < 0 {
+		return -1, errors.New("every party already holds a share of this key")
+	}
+	return newIdx, nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+	newIdx := round.temp.newIdx
+
+	// the new party waits for the share of every helper
+	if round.temp.enrollee {
+		round.ok[i] = true
+		return nil
+	}
+	round.allOK()
+
+	// 1. the other helpers must hold the same epoch of the key
+	for j, msg := range round.temp.enRound1Message1s {
+		if j == i || j == newIdx {
+			continue
+		}
+		if epoch := msg.Content().(*EnrollmentRound1Message1).GetEpoch(); epoch != round.input.Epoch {
+			return round.WrapError(fmt.Errorf("party is enrolling with key epoch %d but ours is %d",
+				epoch, round.input.Epoch), msg.GetFrom())
+		}
+	}
+
+	// 2. verify the dln and paillier proofs of the pre-params of the new party
+	r1msg2 := round.temp.enRound1Message2s[newIdx].Content().(*EnrollmentRound1Message2)
+	Pn := Ps[newIdx]
+	paillierPK, NTilde, H1, H2, err := verifyPreParams(r1msg2, Pn.KeyInt(), round.input)
+	if err != nil {
+		return round.WrapError(err, Pn)
+	}
+	round.temp.newPaillierPK = paillierPK
+	round.temp.newNTilde, round.temp.newH1, round.temp.newH2 = NTilde, H1, H2
+
+	// 3. lambda_i = prod (k_new - kj) / (ki - kj) over the other helpers, so that sum lambda_j*xj = f(k_new)
+	modQ := common.ModInt(round.EC().Params().N)
+	ki, kNew := Pi.KeyInt(), Pn.KeyInt()
+	lambda := big.NewInt(1)
+	for _, Pj := range round.helpers() {
+		kj := Pj.KeyInt()
+		if kj.Cmp(ki) == 0 {
+			continue
+		}
+		// big.Int Div is calculated as: a/b = a * modInv(b,q)
+		lambda = modQ.Mul(lambda, modQ.Mul(modQ.Sub(kNew, kj), modQ.Inverse(modQ.Sub(ki, kj))))
+	}
+
+	// 4. blind lambda_i*xi with the masks sent to and received from the other helpers
+	share := modQ.Mul(lambda, round.input.Xi)
+	for j, msg := range round.temp.enRound1Message1s {
+		if j == i || j == newIdx {
+			continue
+		}
+		share = modQ.Add(share, round.temp.masks[j])
+		share = modQ.Sub(share, msg.Content().(*EnrollmentRound1Message1).UnmarshalMask())
+	}
+
+	// 5. p2p send the blinded share to the new party
+	round.out <- NewEnrollmentRound2Message(Pn, Pi, round.input.Epoch, share)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EnrollmentRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.enRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+// verifyPreParams checks the Paillier key and NTilde, h1, h2 broadcast by the new party of share ID `k` in round 1
+// against the save data `key` of a party of the key, and returns them
+func verifyPreParams(
+	r1msg2 *EnrollmentRound1Message2,
+	k *big.Int,
+	key *keygen.LocalPartySaveData,
+) (paillierPK *paillier.PublicKey, NTilde, H1, H2 *big.Int, err error) {
+	if !r1msg2.ValidateBasic() {
+		return nil, nil, nil, nil, errors.New("the pre-params message of the new party is malformed")
+	}
+	H1, H2, NTilde, paillierPK =
+		r1msg2.UnmarshalH1(),
+		r1msg2.UnmarshalH2(),
+		r1msg2.UnmarshalNTilde(),
+		r1msg2.UnmarshalPaillierPK()
+	if paillierPK.N.BitLen() != paillierBitsLen {
+		return nil, nil, nil, nil, errors.New("got paillier modulus with insufficient bits for this party")
+	}
+	if NTilde.BitLen() != paillierBitsLen {
+		return nil, nil, nil, nil, errors.New("got NTildej with insufficient bits for this party")
+	}
+	if H1.Cmp(H2) == 0 {
+		return nil, nil, nil, nil, errors.New("h1j and h2j were equal for this party")
+	}
+	h1H2Map := make(map[string]struct{}, len(key.Ks)*2)
+	for j := range key.Ks {
+		h1H2Map[hex.EncodeToString(key.H1j[j].Bytes())] = struct{}{}
+		h1H2Map[hex.EncodeToString(key.H2j[j].Bytes())] = struct{}{}
+	}
+	for _, h := range []*big.Int{H1, H2} {
+		if _, found := h1H2Map[hex.EncodeToString(h.Bytes())]; found {
+			return nil, nil, nil, nil, errors.New("this h1j or h2j was already used by another party")
+		}
+	}
+	if dlnProof1, err := r1msg2.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1, H2, NTilde) {
+		return nil, nil, nil, nil, errors.New("dln proof verification failed")
+	}
+	if dlnProof2, err := r1msg2.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2, H1, NTilde) {
+		return nil, nil, nil, nil, errors.New("dln proof verification failed")
+	}
+	if ok, err := r1msg2.UnmarshalPaillierProof().Verify(paillierPK.N, k, key.ECDSAPub); err != nil || !ok {
+		return nil, nil, nil, nil, errors.New("paillier verify failed")
+	}
+	return paillierPK, NTilde, H1, H2, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	newIdx := round.temp.newIdx
+
+	// the helpers wait for the acknowledgement of the new party
+	if !round.temp.enrollee {
+		round.allOK()
+		round.ok[newIdx] = false
+		return nil
+	}
+	round.allOK()
+
+	// 1. xi = sum of the blinded shares of the helpers; the masks cancel out. the helpers must agree on the epoch
+	modQ := common.ModInt(round.EC().Params().N)
+	xi := big.NewInt(0)
+	first := round.helpers()[0].Index
+	epoch := round.temp.enRound2Messages[first].Content().(*EnrollmentRound2Message).GetEpoch()
+	for j, msg := range round.temp.enRound2Messages {
+		if j == newIdx {
+			continue
+		}
+		r2msg := msg.Content().(*EnrollmentRound2Message)
+		if r2msg.GetEpoch() != epoch {
+			return round.WrapError(fmt.Errorf("the helpers disagree on the key epoch (%d != %d)", r2msg.GetEpoch(), epoch))
+		}
+		xi = modQ.Add(xi, r2msg.UnmarshalShare())
+	}
+
+	// 2. the share must lie on the key polynomial; the blinding hides which helper sent a bad share
+	if !crypto.ScalarBaseMult(round.EC(), xi).Equals(round.temp.newBigX) {
+		return round.WrapError(errors.New("the received share does not match the public shares of the helpers"), Pi)
+	}
+
+	// 3. for this P: SAVE the key data extended with our share
+	preParams := round.input.LocalPreParams
+	*round.save = withParty(*round.input, Ps[newIdx].KeyInt(), round.temp.newBigX,
+		&preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i)
+	round.save.LocalSecrets = keygen.LocalSecrets{Xi: xi, ShareID: Pi.KeyInt()}
+	round.save.Epoch = epoch
+
+	// BROADCAST the acknowledgement to the helpers; round 3 message
+	round.out <- NewEnrollmentRound3Message(Pi)
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EnrollmentRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.enRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	if round.temp.enrollee {
+		return nil // finished!
+	}
+	return &round4{round}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"errors"
+
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+
+	// for this P: SAVE the key data extended with the new party, whose share was acknowledged
+	newIdx := round.temp.newIdx
+	*round.save = withParty(*round.input, round.Parties().IDs()[newIdx].KeyInt(), round.temp.newBigX,
+		round.temp.newPaillierPK, round.temp.newNTilde, round.temp.newH1, round.temp.newH2)
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round4) NextRound() tss.Round {
+	return nil // finished!
+}
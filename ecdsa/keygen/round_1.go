@@ -40,13 +40,13 @@ func (round *round1) Start() *tss.Error {
 	i := Pi.Index
 
 	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(tss.EC("ecdsa").Params().N)
+	ui := common.GetRandomPositiveInt(round.EC().Params().N)
 
 	round.temp.ui = ui
 
 	// 2. compute the vss shares
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create("ecdsa", round.Threshold(), ui, ids)
+	vs, shares, err := vss.Create(round.Curve(), round.Threshold(), ui, ids)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
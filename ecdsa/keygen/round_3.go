@@ -65,7 +65,7 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
 				return
 			}
-			PjVs, err := crypto.UnFlattenECPoints(tss.EC("ecdsa"), flatPolyGs)
+			PjVs, err := crypto.UnFlattenECPoints(round.EC(), flatPolyGs)
 			if err != nil {
 				ch <- vssOut{err, nil}
 				return
@@ -76,7 +76,7 @@ func (round *round3) Start() *tss.Error {
 				ID:        round.PartyID().KeyInt(),
 				Share:     r2msg1.UnmarshalShare(),
 			}
-			if ok = PjShare.Verify("ecdsa", round.Threshold(), PjVs); !ok {
+			if ok = PjShare.Verify(round.Curve(), round.Threshold(), PjVs); !ok {
 				ch <- vssOut{errors.New("vss verify failed"), nil}
 				return
 			}
@@ -86,7 +86,7 @@ func (round *round3) Start() *tss.Error {
 	}
 
 	// 1,9. calculate xi (deferred for performance)
-	modQ := common.ModInt(tss.EC("ecdsa").Params().N)
+	modQ := common.ModInt(round.EC().Params().N)
 	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
 	for j := range Ps {
 		if j == PIdx {
@@ -170,7 +170,7 @@ func (round *round3) Start() *tss.Error {
 	}
 
 	// 17. compute and SAVE the ECDSA public key `y`
-	ecdsaPubKey, err := crypto.NewECPoint(tss.EC("ecdsa"), Vc[0].X(), Vc[0].Y())
+	ecdsaPubKey, err := crypto.NewECPoint(round.EC(), Vc[0].X(), Vc[0].Y())
 	if err != nil {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
@@ -8,10 +8,14 @@ package keygen
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"math/big"
 
 	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/envelope"
 	"github.com/sisu-network/tss-lib/crypto/paillier"
 	"github.com/sisu-network/tss-lib/tss"
 )
@@ -47,6 +51,9 @@ type (
 
 		// the ECDSA public key
 		ECDSAPub *crypto.ECPoint // y
+
+		// the key epoch; 0 after keygen and advanced by every refresh or resharing of the key
+		Epoch uint64
 	}
 )
 
@@ -84,6 +91,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.Epoch = sourceData.Epoch
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -98,3 +106,41 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// SaveDataContentType identifies ecdsa keygen save data inside an encrypted envelope
+const SaveDataContentType = "ecdsa-keygen-save-data"
+
+// SaveLocalPartySaveData encrypts `data` under `secret` and writes it to `w` as a versioned envelope.
+// Use this instead of plain JSON, as the save data holds Xi and the Paillier private key.
+func SaveLocalPartySaveData(w io.Writer, data LocalPartySaveData, secret envelope.Secret) error {
+	bz, err := json.Marshal(&data)
+	if err != nil {
+		return err
+	}
+	sealed, err := envelope.Seal(SaveDataContentType, bz, secret)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(sealed)
+	return err
+}
+
+// LoadLocalPartySaveData reads an envelope written by SaveLocalPartySaveData and decrypts it with `secret`.
+// It refuses unknown envelope versions and data that was tampered with. The Paillier secret key is precomputed.
+func LoadLocalPartySaveData(r io.Reader, secret envelope.Secret) (data LocalPartySaveData, err error) {
+	sealed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return
+	}
+	bz, err := envelope.Open(SaveDataContentType, sealed, secret)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(bz, &data); err != nil {
+		return
+	}
+	if data.PaillierSK != nil {
+		err = data.PaillierSK.Precompute()
+	}
+	return
+}
@@ -0,0 +1,41 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/crypto/envelope"
+)
+
+func TestSaveLoadLocalPartySaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	secret := envelope.PassphraseWithParams([]byte("passphrase"), envelope.ScryptParams{N: 1 << 10, R: 8, P: 1})
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, SaveLocalPartySaveData(buf, keys[0], secret))
+	sealed := buf.Bytes()
+	assert.NotContains(t, string(sealed), keys[0].Xi.String())
+
+	loaded, err := LoadLocalPartySaveData(bytes.NewReader(sealed), secret)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, keys[0].Xi.Cmp(loaded.Xi))
+	assert.Equal(t, 0, keys[0].PaillierSK.LambdaN.Cmp(loaded.PaillierSK.LambdaN))
+	assert.True(t, keys[0].ECDSAPub.Equals(loaded.ECDSAPub))
+
+	_, err = LoadLocalPartySaveData(bytes.NewReader(sealed), envelope.Passphrase([]byte("wrong")))
+	assert.Error(t, err)
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-10] ^= 1
+	_, err = LoadLocalPartySaveData(bytes.NewReader(tampered), secret)
+	assert.Error(t, err)
+}
@@ -0,0 +1,290 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+type (
+	// LocalPartyPublicData is the part of LocalPartySaveData that may be shared with the other parties,
+	// e.g. to cross-check the committee with VerifyCommittee.
+	LocalPartyPublicData struct {
+		ShareID *big.Int
+
+		Ks                []*big.Int
+		NTildej, H1j, H2j []*big.Int
+		BigXj             []*crypto.ECPoint
+		PaillierPKs       []*paillier.PublicKey
+
+		ECDSAPub *crypto.ECPoint
+	}
+)
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+// PublicData returns the public part of the save data.
+func (save LocalPartySaveData) PublicData() LocalPartyPublicData {
+	return LocalPartyPublicData{
+		ShareID:     save.ShareID,
+		Ks:          save.Ks,
+		NTildej:     save.NTildej,
+		H1j:         save.H1j,
+		H2j:         save.H2j,
+		BigXj:       save.BigXj,
+		PaillierPKs: save.PaillierPKs,
+		ECDSAPub:    save.ECDSAPub,
+	}
+}
+
+// Validate checks that the save data is internally consistent: the public data passes its own Validate(),
+// Xi*G equals this party's BigXj, and the Paillier and NTilde pre-params match what the other parties hold for us.
+func (save LocalPartySaveData) Validate() error {
+	if err := save.PublicData().Validate(); err != nil {
+		return err
+	}
+	i := save.selfIndex()
+	q := save.ECDSAPub.Curve().Params().N
+	if save.Xi == nil || save.Xi.Sign() <= 0 || save.Xi.Cmp(q) >= 0 {
+		return errors.New("Xi is missing or out of range")
+	}
+	if !crypto.ScalarBaseMult(save.ECDSAPub.Curve(), save.Xi).Equals(save.BigXj[i]) {
+		return errors.New("Xi*G does not match BigXj for this party")
+	}
+
+	if !save.LocalPreParams.Validate() {
+		return errors.New("the local pre-params are missing")
+	}
+	if err := save.PaillierSK.Validate(); err != nil {
+		return err
+	}
+	if save.PaillierSK.N.Cmp(save.PaillierPKs[i].N) != 0 {
+		return errors.New("the Paillier secret key does not match PaillierPKs for this party")
+	}
+	if save.NTildei.Cmp(save.NTildej[i]) != 0 || save.H1i.Cmp(save.H1j[i]) != 0 || save.H2i.Cmp(save.H2j[i]) != 0 {
+		return errors.New("NTildei, H1i or H2i does not match NTildej, H1j or H2j for this party")
+	}
+	if save.LocalPreParams.ValidateWithProof() {
+		P, Q := new(big.Int).Mul(save.P, two), new(big.Int).Mul(save.Q, two)
+		P.Add(P, one)
+		Q.Add(Q, one)
+		if new(big.Int).Mul(P, Q).Cmp(save.NTildei) != 0 {
+			return errors.New("NTildei is not (2P+1)(2Q+1)")
+		}
+		modNTilde := common.ModInt(save.NTildei)
+		if modNTilde.Exp(save.H1i, save.Alpha).Cmp(save.H2i) != 0 ||
+			modNTilde.Exp(save.H2i, save.Beta).Cmp(save.H1i) != 0 {
+			return errors.New("H1i and H2i are not related by Alpha and Beta")
+		}
+	}
+	return nil
+}
+
+// Validate checks that the public data is well-formed: the Ks are distinct and non-zero, every BigXj lies on the
+// same polynomial of degree t whose t+1 point interpolation gives ECDSAPub, and the Paillier and NTilde values
+// of every party are well-formed and unique.
+func (data LocalPartyPublicData) Validate() error {
+	if data.ECDSAPub == nil || !data.ECDSAPub.ValidateBasic() {
+		return errors.New("ECDSAPub is missing or not on the curve")
+	}
+	ec := data.ECDSAPub.Curve()
+	n := len(data.Ks)
+	if n < 2 {
+		return errors.New("the save data must hold at least two parties")
+	}
+	if len(data.NTildej) != n || len(data.H1j) != n || len(data.H2j) != n ||
+		len(data.BigXj) != n || len(data.PaillierPKs) != n {
+		return errors.New("the per-party fields do not all have the same length as Ks")
+	}
+	for j, kj := range data.Ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is missing", j)
+		}
+	}
+	if _, err := vss.CheckIndexes(ec, data.Ks); err != nil {
+		return err
+	}
+	if data.ShareID == nil || data.selfIndex() < 0 {
+		return errors.New("ShareID was not found in Ks")
+	}
+	for j, Xj := range data.BigXj {
+		if Xj == nil || !Xj.ValidateBasic() || Xj.Curve() != ec {
+			return fmt.Errorf("BigXj[%d] is missing or not on the curve", j)
+		}
+	}
+	if _, err := data.Threshold(); err != nil {
+		return err
+	}
+
+	hs := make(map[string]struct{}, 2*n)
+	for j := 0; j < n; j++ {
+		if err := validateModulus(data.NTildej[j], safePrimeBitLen*2); err != nil {
+			return fmt.Errorf("NTildej[%d]: %v", j, err)
+		}
+		if data.PaillierPKs[j] == nil {
+			return fmt.Errorf("PaillierPKs[%d] is missing", j)
+		}
+		if err := validateModulus(data.PaillierPKs[j].N, paillierModulusLen); err != nil {
+			return fmt.Errorf("PaillierPKs[%d]: %v", j, err)
+		}
+		for _, h := range []*big.Int{data.H1j[j], data.H2j[j]} {
+			if h == nil || h.Cmp(one) <= 0 || h.Cmp(data.NTildej[j]) >= 0 ||
+				new(big.Int).GCD(nil, nil, h, data.NTildej[j]).Cmp(one) != 0 {
+				return fmt.Errorf("H1j[%d] or H2j[%d] is not a unit mod NTildej", j, j)
+			}
+			hHex := hex.EncodeToString(h.Bytes())
+			if _, found := hs[hHex]; found {
+				return fmt.Errorf("H1j[%d] or H2j[%d] was already used by another party", j, j)
+			}
+			hs[hHex] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Threshold recovers the threshold t from the public shares: it is the smallest t for which the interpolation
+// of the first t+1 BigXj gives ECDSAPub. An error is returned if no such t exists or if any of the remaining
+// BigXj does not lie on the same polynomial.
+func (data LocalPartyPublicData) Threshold() (int, error) {
+	curve := tss.GetCurveScheme(data.ECDSAPub.Curve())
+	for t := 1; t < len(data.Ks); t++ {
+		y, err := vss.InterpolatePoint(curve, data.Ks[:t+1], data.BigXj[:t+1], zero)
+		if err != nil {
+			return 0, err
+		}
+		if !y.Equals(data.ECDSAPub) {
+			continue
+		}
+		for j := t + 1; j < len(data.Ks); j++ {
+			Xj, err := vss.InterpolatePoint(curve, data.Ks[:t+1], data.BigXj[:t+1], data.Ks[j])
+			if err != nil {
+				return 0, err
+			}
+			if !Xj.Equals(data.BigXj[j]) {
+				return 0, fmt.Errorf("BigXj[%d] is not consistent with the other public shares", j)
+			}
+		}
+		return t, nil
+	}
+	return 0, errors.New("no t+1 BigXj interpolate to ECDSAPub")
+}
+
+// VerifyCommittee checks that the public data of every party in a committee is valid and that all of the parties
+// agree on the key, the public shares and the Paillier and NTilde values of each other. Each party must appear once.
+func VerifyCommittee(parties []LocalPartyPublicData) error {
+	if len(parties) == 0 {
+		return errors.New("VerifyCommittee: no parties were given")
+	}
+	ref := parties[0]
+	if err := ref.Validate(); err != nil {
+		return fmt.Errorf("VerifyCommittee: party 0: %v", err)
+	}
+	if len(parties) != len(ref.Ks) {
+		return fmt.Errorf("VerifyCommittee: expected %d parties but got %d", len(ref.Ks), len(parties))
+	}
+	refIndex := make(map[string]int, len(ref.Ks))
+	for j, kj := range ref.Ks {
+		refIndex[hex.EncodeToString(kj.Bytes())] = j
+	}
+
+	var errs error
+	seen := make(map[string]struct{}, len(parties))
+	for p, data := range parties {
+		if data.ShareID == nil {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID is missing", p))
+			continue
+		}
+		id := hex.EncodeToString(data.ShareID.Bytes())
+		if _, found := seen[id]; found {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID appears more than once", p))
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, found := refIndex[id]; !found {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID is not in the committee", p))
+			continue
+		}
+		if err := data.agreesWith(ref, refIndex); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: %v", p, err))
+		}
+	}
+	return errs
+}
+
+// ----- //
+
+func (save LocalPartySaveData) selfIndex() int {
+	return save.PublicData().selfIndex()
+}
+
+func (data LocalPartyPublicData) selfIndex() int {
+	for j, kj := range data.Ks {
+		if kj != nil && data.ShareID != nil && kj.Cmp(data.ShareID) == 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+func (data LocalPartyPublicData) agreesWith(ref LocalPartyPublicData, refIndex map[string]int) error {
+	if data.ECDSAPub == nil || !data.ECDSAPub.Equals(ref.ECDSAPub) {
+		return errors.New("ECDSAPub differs")
+	}
+	if len(data.Ks) != len(ref.Ks) || len(data.NTildej) != len(ref.Ks) || len(data.H1j) != len(ref.Ks) ||
+		len(data.H2j) != len(ref.Ks) || len(data.BigXj) != len(ref.Ks) || len(data.PaillierPKs) != len(ref.Ks) {
+		return errors.New("the number of parties differs")
+	}
+	for j, kj := range data.Ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is missing", j)
+		}
+		r, found := refIndex[hex.EncodeToString(kj.Bytes())]
+		if !found {
+			return fmt.Errorf("Ks[%d] is not in the committee", j)
+		}
+		if data.BigXj[j] == nil || !data.BigXj[j].Equals(ref.BigXj[r]) {
+			return fmt.Errorf("BigXj differs for Ks[%d]", j)
+		}
+		if !equalInts(data.NTildej[j], ref.NTildej[r]) || !equalInts(data.H1j[j], ref.H1j[r]) ||
+			!equalInts(data.H2j[j], ref.H2j[r]) {
+			return fmt.Errorf("NTildej, H1j or H2j differs for Ks[%d]", j)
+		}
+		if data.PaillierPKs[j] == nil || !equalInts(data.PaillierPKs[j].N, ref.PaillierPKs[r].N) {
+			return fmt.Errorf("PaillierPKs differs for Ks[%d]", j)
+		}
+	}
+	return nil
+}
+
+func validateModulus(N *big.Int, bitLen int) error {
+	if N == nil {
+		return errors.New("modulus is missing")
+	}
+	// the product of two bitLen/2-bit primes is either bitLen or bitLen-1 bits long
+	if N.BitLen() < bitLen-1 || N.Bit(0) == 0 {
+		return fmt.Errorf("modulus is not an odd %d-bit integer", bitLen)
+	}
+	return nil
+}
+
+func equalInts(a, b *big.Int) bool {
+	return a != nil && b != nil && a.Cmp(b) == 0
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/crypto"
+)
+
+func TestSaveDataValidate(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(TestParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	for _, key := range keys {
+		assert.NoError(t, key.Validate())
+		threshold, err := key.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, TestThreshold, threshold)
+	}
+
+	key := keys[0]
+	key.Xi = new(big.Int).Add(keys[0].Xi, big.NewInt(1))
+	assert.Error(t, key.Validate(), "Xi*G must match BigXj")
+
+	key = keys[0]
+	key.BigXj = append([]*crypto.ECPoint{}, keys[0].BigXj...)
+	key.BigXj[len(key.BigXj)-1] = key.BigXj[0]
+	assert.Error(t, key.Validate(), "every BigXj must lie on the polynomial")
+
+	key = keys[0]
+	key.Ks = append([]*big.Int{}, keys[0].Ks...)
+	key.Ks[1] = key.Ks[2]
+	assert.Error(t, key.Validate(), "Ks must be distinct")
+
+	key = keys[0]
+	key.H2j = append([]*big.Int{}, keys[0].H2j...)
+	key.H2j[1] = key.H1j[1]
+	assert.Error(t, key.Validate(), "H1j and H2j must be unique")
+
+	key = keys[0]
+	key.LocalPreParams = keys[1].LocalPreParams
+	assert.Error(t, key.Validate(), "the pre-params must belong to this party")
+}
+
+func TestVerifyCommittee(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(TestParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pubs := make([]LocalPartyPublicData, len(keys))
+	for i, key := range keys {
+		pubs[i] = key.PublicData()
+	}
+	assert.NoError(t, VerifyCommittee(pubs))
+
+	assert.Error(t, VerifyCommittee(pubs[1:]), "every party must be present")
+
+	dup := append([]LocalPartyPublicData{}, pubs...)
+	dup[1] = dup[0]
+	assert.Error(t, VerifyCommittee(dup), "every party must appear once")
+
+	bad := append([]LocalPartyPublicData{}, pubs...)
+	bad[2].BigXj = append([]*crypto.ECPoint{}, pubs[2].BigXj...)
+	bad[2].BigXj[3] = bad[2].BigXj[4]
+	assert.Error(t, VerifyCommittee(bad), "the parties must agree on the public shares")
+}
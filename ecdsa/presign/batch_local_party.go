@@ -0,0 +1,391 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*BatchLocalParty)(nil)
+var _ fmt.Stringer = (*BatchLocalParty)(nil)
+var _ tss.Round = (*batchRound)(nil)
+
+type (
+	// BatchLocalParty runs `batchSize` independent presign instances for the same key and signer set in one session.
+	// In each round the messages of all instances for the same recipient travel together in one PresignBatchMessage,
+	// so N presignatures cost the same number of network round-trips as one.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		instances []*LocalParty
+		outs      []chan tss.Message
+		ends      []chan *LocalPresignData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []*LocalPresignData
+	}
+
+	// batchRound steps the rounds of every instance together
+	batchRound struct {
+		*tss.Parameters
+		party *BatchLocalParty
+		inner []tss.Round
+	}
+
+	// InstanceError reports why the instance at Index of a batch failed and which parties are to blame for it.
+	// The cause of a *tss.Error returned by a batch party is a *multierror.Error of InstanceErrors when a failure is
+	// specific to some instances; use InstanceErrors to retrieve them.
+	InstanceError struct {
+		Index    int
+		Culprits []*tss.PartyID
+		Err      error
+	}
+)
+
+// NewBatchLocalParty returns a party that produces `batchSize` presignatures in one run.
+// They are sent on `end` together, in the same order at every party.
+func NewBatchLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	batchSize int,
+	out chan<- tss.Message,
+	end chan<- []*LocalPresignData,
+	optionalRandomness ...*paillier.RandomnessStore,
+) tss.Party {
+	if batchSize < 1 {
+		panic(errors.New("presign.NewBatchLocalParty expected a batchSize of at least 1"))
+	}
+	partyCount := len(params.Parties().IDs())
+	p := &BatchLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		instances: make([]*LocalParty, batchSize),
+		outs:      make([]chan tss.Message, batchSize),
+		ends:      make([]chan *LocalPresignData, batchSize),
+		out:       out,
+		end:       end,
+	}
+	for k := range p.instances {
+		// a round sends at most one P2P message to each other party and one broadcast
+		p.outs[k] = make(chan tss.Message, 2*partyCount)
+		p.ends[k] = make(chan *LocalPresignData, 1)
+		p.instances[k] = NewLocalParty(params, key, p.outs[k], p.ends[k], optionalRandomness...).(*LocalParty)
+	}
+	return p
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	inner := make([]tss.Round, len(p.instances))
+	for k, instance := range p.instances {
+		inner[k] = instance.FirstRound()
+	}
+	return &batchRound{p.params, p, inner}
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		batch, ok := round.(*batchRound)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		for _, inner := range batch.inner {
+			round1, ok := inner.(*round1)
+			if !ok {
+				return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+			}
+			// the key and signer set are the same for every instance, so the first failure says it all
+			if err := round1.prepare(); err != nil {
+				return round.WrapError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+// StoreMessage unpacks a PresignBatchMessage and stores each entry with its instance.
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	batch, ok := msg.Content().(*PresignBatchMessage)
+	if !ok {
+		return false, nil
+	}
+	Pj := msg.GetFrom()
+	if len(batch.GetInstances()) != len(p.instances) {
+		return false, p.WrapError(fmt.Errorf("expected %d instances in a batch message but got %d",
+			len(p.instances), len(batch.GetInstances())), Pj)
+	}
+	msgs := make([]tss.ParsedMessage, len(p.instances))
+	instErrs := make([]*InstanceError, 0)
+	for k, bz := range batch.GetInstances() {
+		inner, err := tss.ParseWireMessage(bz, Pj, msg.IsBroadcast())
+		if err == nil && k > 0 && inner.Type() != msgs[0].Type() {
+			err = fmt.Errorf("expected a %s but got a %s", msgs[0].Type(), inner.Type())
+		}
+		if err != nil {
+			instErrs = append(instErrs, &InstanceError{k, []*tss.PartyID{Pj}, err})
+			if k == 0 {
+				break // nothing to compare the other instances with
+			}
+			continue
+		}
+		msgs[k] = inner
+	}
+	if len(instErrs) > 0 {
+		return false, p.wrapInstanceErrors(instErrs)
+	}
+	for k, inner := range msgs {
+		if ok, err := p.instances[k].StoreMessage(inner); err != nil {
+			instErrs = append(instErrs, toInstanceError(k, err))
+		} else if !ok {
+			return false, nil
+		}
+	}
+	if len(instErrs) > 0 {
+		return false, p.wrapInstanceErrors(instErrs)
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// wrapInstanceErrors returns a *tss.Error whose cause lists every InstanceError and whose culprits are all of the
+// parties blamed in any instance.
+func (p *BatchLocalParty) wrapInstanceErrors(instErrs []*InstanceError) *tss.Error {
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(p.params.Parties().IDs()))
+	blamed := make(map[int]struct{}, len(p.params.Parties().IDs()))
+	for _, instErr := range instErrs {
+		multiErr = multierror.Append(multiErr, instErr)
+		for _, Pj := range instErr.Culprits {
+			if _, found := blamed[Pj.Index]; found {
+				continue
+			}
+			blamed[Pj.Index] = struct{}{}
+			culprits = append(culprits, Pj)
+		}
+	}
+	return p.WrapError(multiErr, culprits...)
+}
+
+// flush packs the messages that the instances sent in the round that just started into one batch message per
+// recipient. Every instance sends the same kinds of messages to the same parties in the same order.
+func (p *BatchLocalParty) flush() error {
+	msgs := make([][]tss.Message, len(p.instances))
+	for k, out := range p.outs {
+	drain:
+		for {
+			select {
+			case msg := <-out:
+				msgs[k] = append(msgs[k], msg)
+			default:
+				break drain
+			}
+		}
+		if len(msgs[k]) != len(msgs[0]) {
+			return fmt.Errorf("instance %d sent %d messages but instance 0 sent %d", k, len(msgs[k]), len(msgs[0]))
+		}
+	}
+	for m, first := range msgs[0] {
+		instances := make([][]byte, len(p.instances))
+		for k := range msgs {
+			msg := msgs[k][m]
+			if msg.Type() != first.Type() || !sameRecipients(msg.GetTo(), first.GetTo()) {
+				return fmt.Errorf("instance %d sent a %s where instance 0 sent a %s", k, msg.Type(), first.Type())
+			}
+			bz, _, err := msg.WireBytes()
+			if err != nil {
+				return err
+			}
+			instances[k] = bz
+		}
+		p.out <- NewPresignBatchMessage(p.PartyID(), first.GetTo(), instances)
+	}
+	return nil
+}
+
+// ----- //
+
+func (round *batchRound) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *batchRound) RoundNumber() int {
+	return round.inner[0].RoundNumber()
+}
+
+func (round *batchRound) Start() *tss.Error {
+	instErrs := make([]*InstanceError, 0)
+	for k, inner := range round.inner {
+		if err := inner.Start(); err != nil {
+			instErrs = append(instErrs, toInstanceError(k, err))
+		}
+	}
+	if len(instErrs) > 0 {
+		return round.party.wrapInstanceErrors(instErrs)
+	}
+	if err := round.party.flush(); err != nil {
+		return round.WrapError(err)
+	}
+	if _, ok := round.inner[0].(*finalization); ok {
+		data := make([]*LocalPresignData, len(round.inner))
+		for k, end := range round.party.ends {
+			data[k] = <-end
+		}
+		round.party.end <- data
+	}
+	return nil
+}
+
+func (round *batchRound) Update() (bool, *tss.Error) {
+	ok := true
+	instErrs := make([]*InstanceError, 0)
+	for k, inner := range round.inner {
+		innerOK, err := inner.Update()
+		if err != nil {
+			instErrs = append(instErrs, toInstanceError(k, err))
+		}
+		ok = ok && innerOK
+	}
+	if len(instErrs) > 0 {
+		return false, round.party.wrapInstanceErrors(instErrs)
+	}
+	return ok, nil
+}
+
+func (round *batchRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignBatchMessage); ok {
+		return true
+	}
+	return false
+}
+
+func (round *batchRound) CanProceed() bool {
+	for _, inner := range round.inner {
+		if !inner.CanProceed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *batchRound) NextRound() tss.Round {
+	next := make([]tss.Round, len(round.inner))
+	for k, inner := range round.inner {
+		if next[k] = inner.NextRound(); next[k] == nil {
+			return nil // finished!
+		}
+	}
+	return &batchRound{round.Parameters, round.party, next}
+}
+
+// WaitingFor returns the parties that any of the instances is still waiting for
+func (round *batchRound) WaitingFor() []*tss.PartyID {
+	ids := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	seen := make(map[int]struct{}, len(round.Parties().IDs()))
+	for _, inner := range round.inner {
+		for _, Pj := range inner.WaitingFor() {
+			if _, found := seen[Pj.Index]; found {
+				continue
+			}
+			seen[Pj.Index] = struct{}{}
+			ids = append(ids, Pj)
+		}
+	}
+	return ids
+}
+
+func (round *batchRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.RoundNumber(), round.PartyID(), culprits...)
+}
+
+// ----- //
+
+func (err *InstanceError) Error() string {
+	return fmt.Sprintf("instance %d, culprits %s: %v", err.Index, err.Culprits, err.Err)
+}
+
+func (err *InstanceError) Unwrap() error { return err.Err }
+
+// InstanceErrors returns the per-instance failures carried by an error returned from a batch party, or nil if
+// there are none.
+func InstanceErrors(err error) []*InstanceError {
+	tssErr, ok := err.(*tss.Error)
+	if !ok || tssErr == nil {
+		return nil
+	}
+	multiErr, ok := tssErr.Cause().(*multierror.Error)
+	if !ok {
+		return nil
+	}
+	instErrs := make([]*InstanceError, 0, len(multiErr.Errors))
+	for _, e := range multiErr.Errors {
+		if instErr, ok := e.(*InstanceError); ok {
+			instErrs = append(instErrs, instErr)
+		}
+	}
+	return instErrs
+}
+
+func toInstanceError(k int, err *tss.Error) *InstanceError {
+	return &InstanceError{Index: k, Culprits: err.Culprits(), Err: err.Cause()}
+}
+
+func sameRecipients(a, b []*tss.PartyID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for j := range a {
+		if a[j].Index != b[j].Index {
+			return false
+		}
+	}
+	return true
+}
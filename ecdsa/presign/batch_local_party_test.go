@@ -0,0 +1,135 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const testBatchSize = 3
+
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*BatchLocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, 2*len(signPIDs)*len(signPIDs))
+	endCh := make(chan []*LocalPresignData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// the MtA randomness is taken from pools that are filled in the background
+	randomness := paillier.NewRandomnessStore(testBatchSize)
+	defer randomness.Close()
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewBatchLocalParty(params, keys[i], testBatchSize, outCh, endCh, randomness).(*BatchLocalParty)
+		parties = append(parties, P)
+	}
+	// start every party before routing so that no message arrives ahead of its recipient's first round
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	batches := make([][]*LocalPresignData, len(signPIDs))
+	var ended int32
+presign:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if !assert.Len(t, data, testBatchSize) {
+				return
+			}
+			for i, P := range parties {
+				if P.PartyID().Id == data[0].PartyId {
+					batches[i] = data
+				}
+			}
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				t.Logf("Done. Received %d presignatures from %d participants", testBatchSize, ended)
+				break presign
+			}
+		}
+	}
+
+	ec := tss.EC("ecdsa")
+	modN := common.ModInt(ec.Params().N)
+	pk := ecdsa.PublicKey{Curve: ec, X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	msg := common.GetRandomPrimeInt(256)
+	seen := make(map[string]struct{}, testBatchSize)
+	for k := 0; k < testBatchSize; k++ {
+		sumS := big.NewInt(0)
+		for i := range parties {
+			sumS = modN.Add(sumS, calculateSi(batches[i][k], msg))
+		}
+		rx := new(big.Int).SetBytes(batches[0][k].BigR.GetX())
+		assert.Truef(t, ecdsa.Verify(&pk, msg.Bytes(), rx, sumS), "ecdsa verify must pass for instance %d", k)
+
+		_, dup := seen[rx.String()]
+		assert.Falsef(t, dup, "instance %d should have its own R", k)
+		seen[rx.String()] = struct{}{}
+	}
+}
+
+func TestInstanceErrors(t *testing.T) {
+	_, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	instErr := &InstanceError{Index: 1, Culprits: pIDs[2:3], Err: errors.New("Type 7 identified abort")}
+	tssErr := tss.NewError(multierror.Append(nil, instErr), TaskName, 7, pIDs[0], pIDs[2])
+	instErrs := InstanceErrors(tssErr)
+	if assert.Len(t, instErrs, 1) {
+		assert.Equal(t, 1, instErrs[0].Index)
+		assert.Equal(t, pIDs[2], instErrs[0].Culprits[0])
+	}
+	assert.Empty(t, InstanceErrors(tss.NewError(errors.New("other"), TaskName, 1, pIDs[0])))
+}
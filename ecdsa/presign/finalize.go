@@ -43,7 +43,7 @@ func (round *finalization) Start() *tss.Error {
 	// Identifiable Abort Type 7 triggered during Phase 6 (GG20)
 	if round.abortingT7 {
 		common.Logger.Infof("round 8: Abort Type 7 code path triggered")
-		q := tss.EC("ecdsa").Params().N
+		q := round.EC().Params().N
 		kIs := make([][]byte, len(Ps))
 		gMus := make([][]*crypto.ECPoint, len(Ps))
 		gNus := make([][]*crypto.ECPoint, len(Ps))
@@ -75,7 +75,7 @@ func (round *finalization) Start() *tss.Error {
 
 			// keep k_i and the g^sigma_i proof for later
 			kIs[j] = r7msg.GetKI()
-			if gSigmaIPfs[j], err = r7msg.UnmarshalSigmaIProof(); err != nil {
+			if gSigmaIPfs[j], err = r7msg.UnmarshalSigmaIProof(round.Curve()); err != nil {
 				culprits = append(culprits, Pj)
 				continue
 			}
@@ -113,7 +113,7 @@ func (round *finalization) Start() *tss.Error {
 				if k == j {
 					continue
 				}
-				gMus[j][k] = crypto.ScalarBaseMult(tss.EC("ecdsa"), mu.Mod(mu, q))
+				gMus[j][k] = crypto.ScalarBaseMult(round.EC(), mu.Mod(mu, q))
 			}
 		}
 		bigR := round.temp.rI
@@ -143,8 +143,8 @@ func (round *finalization) Start() *tss.Error {
 				gSigmaI, _ = gSigmaI.Add(gMuIJ)
 				gSigmaI, _ = gSigmaI.Add(gNuJI)
 			}
-			bigSI, _ := crypto.NewECPointFromProtobuf("ecdsa", round.temp.BigSJ[P.Id])
-			if !gSigmaIPfs[i].VerifySigmaI(tss.EC("ecdsa"), gSigmaI, bigR, bigSI) {
+			bigSI, _ := crypto.NewECPointFromProtobuf(round.Curve(), round.temp.BigSJ[P.Id])
+			if !gSigmaIPfs[i].VerifySigmaI(round.EC(), gSigmaI, bigR, bigSI) {
 				culprits = append(culprits, P)
 				continue
 			}
@@ -156,6 +156,7 @@ func (round *finalization) Start() *tss.Error {
 	// We have successfully generated local presgin data.
 	round.temp.LocalPresignData.PartyId = round.PartyID().Id
 	round.temp.LocalPresignData.ECDSAPub = round.key.ECDSAPub
+	round.temp.LocalPresignData.KeyEpoch = round.key.Epoch
 
 	round.end <- round.temp.LocalPresignData
 
@@ -15,6 +15,7 @@ import (
 	"github.com/sisu-network/tss-lib/crypto"
 	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
 	"github.com/sisu-network/tss-lib/crypto/mta"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
 	"github.com/sisu-network/tss-lib/ecdsa/keygen"
 	"github.com/sisu-network/tss-lib/tss"
 )
@@ -86,15 +87,21 @@ type (
 		rI,
 		TI *crypto.ECPoint
 		r7AbortData PresignRound7Message_AbortData
+
+		// precomputed Paillier randomness for MtA; nil to compute it online
+		randomness *paillier.RandomnessStore
 	}
 )
 
 // Constructs a new ECDSA presign party.
+// When `optionalRandomness` is provided, the Paillier randomness of MtA is taken from its pools instead of being
+// computed online.
 func NewLocalParty(
 	params *tss.Parameters,
 	key keygen.LocalPartySaveData,
 	out chan<- tss.Message,
 	end chan<- *LocalPresignData,
+	optionalRandomness ...*paillier.RandomnessStore,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
@@ -105,6 +112,12 @@ func NewLocalParty(
 		out:       out,
 		end:       end,
 	}
+	if 0 < len(optionalRandomness) {
+		if 1 < len(optionalRandomness) {
+			panic(errors.New("presign.NewLocalParty expected 0 or 1 item in `optionalRandomness`"))
+		}
+		p.temp.randomness = optionalRandomness[0]
+	}
 	// msgs init
 	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
 	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
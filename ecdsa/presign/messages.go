@@ -131,8 +131,8 @@ func (m *PresignRound2Message) UnmarshalProofBob() (*mta.ProofBob, error) {
 	return mta.ProofBobFromBytes(m.GetProofBob())
 }
 
-func (m *PresignRound2Message) UnmarshalProofBobWC() (*mta.ProofBobWC, error) {
-	return mta.ProofBobWCFromBytes("ecdsa", m.GetProofBobWc())
+func (m *PresignRound2Message) UnmarshalProofBobWC(curve string) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(curve, m.GetProofBobWc())
 }
 
 // ----- //
@@ -165,39 +165,43 @@ func NewPresignRound3Message(
 }
 
 func (m *PresignRound3Message) ValidateBasic() bool {
-	if m == nil ||
-		m.GetTI() == nil ||
-		!m.GetTI().ValidateBasic() ||
-		!common.NonEmptyBytes(m.GetDeltaI()) ||
-		!common.NonEmptyBytes(m.GetTProofT()) ||
-		!common.NonEmptyBytes(m.GetTProofU()) {
-		return false
-	}
-	TI, err := m.UnmarshalTI()
+	// the points and the TProof depend on the curve of the key, so they are checked by round 5 (VerifyTProof)
+	return m != nil &&
+		m.GetTI() != nil &&
+		m.GetTI().ValidateBasic() &&
+		m.GetTProofAlpha() != nil &&
+		m.GetTProofAlpha().ValidateBasic() &&
+		common.NonEmptyBytes(m.GetDeltaI()) &&
+		common.NonEmptyBytes(m.GetTProofT()) &&
+		common.NonEmptyBytes(m.GetTProofU())
+}
+
+// VerifyTProof checks that TI is on the curve and verifies its TProof.
+func (m *PresignRound3Message) VerifyTProof(curve string) bool {
+	TI, err := m.UnmarshalTI(curve)
 	if err != nil {
 		return false
 	}
-	tProof, err := m.UnmarshalTProof()
+	tProof, err := m.UnmarshalTProof(curve)
 	if err != nil {
 		return false
 	}
-	// we have everything we need to validate the TProof here!
-	basePoint2, err := crypto.ECBasePoint2(tss.EC("ecdsa"))
+	basePoint2, err := crypto.ECBasePoint2(tss.EC(curve))
 	if err != nil {
 		return false
 	}
-	return TI.ValidateBasic() && tProof.Verify("ecdsa", TI, basePoint2)
+	return TI.ValidateBasic() && tProof.Verify(curve, TI, basePoint2)
 }
 
-func (m *PresignRound3Message) UnmarshalTI() (*crypto.ECPoint, error) {
+func (m *PresignRound3Message) UnmarshalTI(curve string) (*crypto.ECPoint, error) {
 	if m.GetTI() == nil || !m.GetTI().ValidateBasic() {
 		return nil, errors.New("UnmarshalTI() X or Y coord is nil or did not validate")
 	}
-	return crypto.NewECPointFromProtobuf("ecdsa", m.GetTI())
+	return crypto.NewECPointFromProtobuf(curve, m.GetTI())
 }
 
-func (m *PresignRound3Message) UnmarshalTProof() (*zkp.TProof, error) {
-	alpha, err := crypto.NewECPointFromProtobuf("ecdsa", m.GetTProofAlpha())
+func (m *PresignRound3Message) UnmarshalTProof(curve string) (*zkp.TProof, error) {
+	alpha, err := crypto.NewECPointFromProtobuf(curve, m.GetTProofAlpha())
 	if err != nil {
 		return nil, err
 	}
@@ -260,25 +264,19 @@ func NewPresignRound5Message(
 }
 
 func (m *PresignRound5Message) ValidateBasic() bool {
-	if m == nil ||
-		m.GetRI() == nil ||
-		!m.GetRI().ValidateBasic() ||
-		!common.NonEmptyMultiBytes(m.GetProofPdlWSlack(), zkp.PDLwSlackMarshalledParts) {
-		return false
-	}
-	RI, err := m.UnmarshalRI()
-	if err != nil {
-		return false
-	}
-	return RI.ValidateBasic()
+	// RI is checked to be on the curve of the key by UnmarshalRI in round 6
+	return m != nil &&
+		m.GetRI() != nil &&
+		m.GetRI().ValidateBasic() &&
+		common.NonEmptyMultiBytes(m.GetProofPdlWSlack(), zkp.PDLwSlackMarshalledParts)
 }
 
-func (m *PresignRound5Message) UnmarshalRI() (*crypto.ECPoint, error) {
-	return crypto.NewECPointFromProtobuf("ecdsa", m.GetRI())
+func (m *PresignRound5Message) UnmarshalRI(curve string) (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(curve, m.GetRI())
 }
 
-func (m *PresignRound5Message) UnmarshalPDLwSlackProof() (*zkp.PDLwSlackProof, error) {
-	return zkp.UnmarshalPDLwSlackProof("ecdsa", m.GetProofPdlWSlack())
+func (m *PresignRound5Message) UnmarshalPDLwSlackProof(curve string) (*zkp.PDLwSlackProof, error) {
+	return zkp.UnmarshalPDLwSlackProof(curve, m.GetProofPdlWSlack())
 }
 
 // ----- //
@@ -333,26 +331,16 @@ func (m *PresignRound6Message) ValidateBasic() bool {
 	}
 	switch c := m.GetContent().(type) {
 	case *PresignRound6Message_Success:
-		if c.Success == nil ||
-			c.Success.GetSI() == nil ||
-			!c.Success.GetSI().ValidateBasic() ||
-			c.Success.GetStProofAlpha() == nil ||
-			c.Success.GetStProofBeta() == nil ||
-			!c.Success.GetStProofAlpha().ValidateBasic() ||
-			!c.Success.GetStProofBeta().ValidateBasic() ||
-			!common.NonEmptyBytes(c.Success.GetStProofT()) ||
-			!common.NonEmptyBytes(c.Success.GetStProofU()) {
-			return false
-		}
-		sI, err := c.Success.UnmarshalSI()
-		if err != nil {
-			return false
-		}
-		tProof, err := c.Success.UnmarshalSTProof()
-		if err != nil {
-			return false
-		}
-		return sI.ValidateBasic() && tProof.ValidateBasic()
+		// SI and the STProof are checked to be on the curve of the key by round 7
+		return c.Success != nil &&
+			c.Success.GetSI() != nil &&
+			c.Success.GetSI().ValidateBasic() &&
+			c.Success.GetStProofAlpha() != nil &&
+			c.Success.GetStProofBeta() != nil &&
+			c.Success.GetStProofAlpha().ValidateBasic() &&
+			c.Success.GetStProofBeta().ValidateBasic() &&
+			common.NonEmptyBytes(c.Success.GetStProofT()) &&
+			common.NonEmptyBytes(c.Success.GetStProofU())
 	case *PresignRound6Message_Abort:
 		return c.Abort != nil &&
 			common.NonEmptyBytes(c.Abort.GetKI()) &&
@@ -364,16 +352,16 @@ func (m *PresignRound6Message) ValidateBasic() bool {
 	}
 }
 
-func (m *PresignRound6Message_SuccessData) UnmarshalSI() (*crypto.ECPoint, error) {
-	return crypto.NewECPointFromProtobuf("ecdsa", m.GetSI())
+func (m *PresignRound6Message_SuccessData) UnmarshalSI(curve string) (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(curve, m.GetSI())
 }
 
-func (m *PresignRound6Message_SuccessData) UnmarshalSTProof() (*zkp.STProof, error) {
-	alpha, err := crypto.NewECPointFromProtobuf("ecdsa", m.GetStProofAlpha())
+func (m *PresignRound6Message_SuccessData) UnmarshalSTProof(curve string) (*zkp.STProof, error) {
+	alpha, err := crypto.NewECPointFromProtobuf(curve, m.GetStProofAlpha())
 	if err != nil {
 		return nil, err
 	}
-	beta, err := crypto.NewECPointFromProtobuf("ecdsa", m.GetStProofBeta())
+	beta, err := crypto.NewECPointFromProtobuf(curve, m.GetStProofBeta())
 	if err != nil {
 		return nil, err
 	}
@@ -442,12 +430,12 @@ func (m *PresignRound7Message) ValidateBasic() bool {
 	}
 }
 
-func (m *PresignRound7Message_AbortData) UnmarshalSigmaIProof() (*zkp.ECDDHProof, error) {
-	a1, err := crypto.NewECPointFromProtobuf("ecdsa", m.GetEcddhProofA1())
+func (m *PresignRound7Message_AbortData) UnmarshalSigmaIProof(curve string) (*zkp.ECDDHProof, error) {
+	a1, err := crypto.NewECPointFromProtobuf(curve, m.GetEcddhProofA1())
 	if err != nil {
 		return nil, err
 	}
-	a2, err := crypto.NewECPointFromProtobuf("ecdsa", m.GetEcddhProofA2())
+	a2, err := crypto.NewECPointFromProtobuf(curve, m.GetEcddhProofA2())
 	if err != nil {
 		return nil, err
 	}
@@ -457,3 +445,28 @@ func (m *PresignRound7Message_AbortData) UnmarshalSigmaIProof() (*zkp.ECDDHProof
 		Z:  new(big.Int).SetBytes(m.GetEcddhProofZ()),
 	}, nil
 }
+
+// ----- //
+
+func NewPresignBatchMessage(
+	from *tss.PartyID,
+	to []*tss.PartyID,
+	instances [][]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: to == nil,
+	}
+	content := &PresignBatchMessage{
+		Instances: instances,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+// ValidateBasic only checks the shape of the batch; each entry is validated by its instance once it is unpacked
+func (m *PresignBatchMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetInstances())
+}
@@ -0,0 +1,307 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/envelope"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+var (
+	ErrPoolEmpty      = errors.New("presign pool: no unused presignature for this key, epoch and signer set")
+	ErrPresignExists  = errors.New("presign pool: the presignature was already added to the pool")
+	ErrPresignUnknown = errors.New("presign pool: no unused presignature with this id")
+	ErrPresignInvalid = errors.New("presign pool: the presign data is missing its party, key, R or signer set")
+)
+
+type (
+	// PoolStore is the durable storage behind a Pool. Entries are grouped by an index (the party that owns them, the
+	// key, its epoch and the signer set) and named by an id that is unique to the presignature. An implementation
+	// must guarantee that Take and TakeID never return the same entry twice, including to other processes sharing the
+	// store, and that an id can never be stored again once it has been taken.
+	PoolStore interface {
+		// Put stores `entry` under `index` and `id`, or returns ErrPresignExists if `id` was ever stored before.
+		Put(index, id string, entry []byte) error
+		// Take marks one unused entry under `index` as consumed and then returns it, or returns ErrPoolEmpty.
+		Take(index string) ([]byte, error)
+		// TakeID marks the unused entry `id` under `index` as consumed and then returns it, or returns
+		// ErrPresignUnknown.
+		TakeID(index, id string) ([]byte, error)
+		// Count returns the number of unused entries under `index`.
+		Count(index string) (int, error)
+	}
+
+	// Pool holds presignatures until they are used to sign. The entries are sealed with `secret` before they reach
+	// the store, and each one is handed out by TakeByID or Take at most once.
+	Pool struct {
+		store  PoolStore
+		secret envelope.Secret
+	}
+
+	// FileStore is a PoolStore that keeps each entry in its own file under a directory. Take claims an entry by
+	// renaming it, which is atomic on a local file system, so processes on the same host may share a FileStore.
+	// A consumed entry is kept as an empty file so that the same presignature cannot be added again.
+	FileStore struct {
+		dir string
+	}
+)
+
+const (
+	unusedExt   = ".presign"
+	consumedExt = ".used"
+)
+
+// NewPool returns a pool that seals its entries with `secret` and keeps them in `store`.
+func NewPool(store PoolStore, secret envelope.Secret) *Pool {
+	return &Pool{store: store, secret: secret}
+}
+
+// Add stores a presignature produced by a presign party. It is indexed by the party that holds it, by its ECDSAPub,
+// by its KeyEpoch and by the signer set that produced it, so the parties of a session may share a store. Adding a
+// presignature that is or was in the pool returns ErrPresignExists.
+func (p *Pool) Add(data *LocalPresignData) error {
+	if data == nil || data.PartyId == "" || data.ECDSAPub == nil || data.BigR == nil || len(data.BigSJ) == 0 {
+		return ErrPresignInvalid
+	}
+	signers := make([]string, 0, len(data.BigSJ))
+	for id := range data.BigSJ {
+		signers = append(signers, id)
+	}
+	bz, err := data.Marshall()
+	if err != nil {
+		return err
+	}
+	sealed, err := envelope.Seal(PresignDataContentType, bz, p.secret)
+	if err != nil {
+		return err
+	}
+	return p.store.Put(poolIndex(data.PartyId, data.ECDSAPub, data.KeyEpoch, signers), PoolID(data), sealed)
+}
+
+// PoolID returns the id of a presignature in a Pool, the digest of its R. R is the same for every party of the presign
+// session, so a coordinator can announce the id of one of its presignatures for every signer to pass to TakeByID.
+func PoolID(data *LocalPresignData) string {
+	return poolDigest(data.BigR.GetX(), data.BigR.GetY())
+}
+
+// TakeByID removes and returns the presignature `id` (see PoolID) of `owner` for `pub` at the key epoch `keyEpoch`
+// that was made by exactly the parties in `signers`. This is how the signers of a session agree on the presignature
+// they use, even if their pools have drifted apart; ErrPresignUnknown is returned if `owner` has no unused one with
+// this id. Pass the Epoch of the save data that will sign: presignatures made before a refresh or reshare are under
+// an older epoch, so they are never handed out to be rejected by signing.
+// The entry is marked as consumed in the store before it is returned, so a crash after TakeByID loses the
+// presignature rather than risking its reuse.
+func (p *Pool) TakeByID(
+	pub *crypto.ECPoint,
+	keyEpoch uint64,
+	signers []*tss.PartyID,
+	owner *tss.PartyID,
+	id string,
+) (*LocalPresignData, error) {
+	sealed, err := p.store.TakeID(poolIndex(owner.Id, pub, keyEpoch, partyIDs(signers)), id)
+	if err != nil {
+		return nil, err
+	}
+	return p.open(sealed)
+}
+
+// Take is TakeByID for whichever unused presignature of `owner` comes first, or ErrPoolEmpty when there is none
+// left. Only use it when a single party signs from the pool, as the pools of the other signers may pick another.
+func (p *Pool) Take(
+	pub *crypto.ECPoint,
+	keyEpoch uint64,
+	signers []*tss.PartyID,
+	owner *tss.PartyID,
+) (*LocalPresignData, error) {
+	sealed, err := p.store.Take(poolIndex(owner.Id, pub, keyEpoch, partyIDs(signers)))
+	if err != nil {
+		return nil, err
+	}
+	return p.open(sealed)
+}
+
+func (p *Pool) open(sealed []byte) (*LocalPresignData, error) {
+	bz, err := envelope.Open(PresignDataContentType, sealed, p.secret)
+	if err != nil {
+		return nil, err
+	}
+	data := new(LocalPresignData)
+	if err = json.Unmarshal(bz, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Count returns the number of unused presignatures of `owner` for `pub` at `keyEpoch` and `signers`.
+func (p *Pool) Count(pub *crypto.ECPoint, keyEpoch uint64, signers []*tss.PartyID, owner *tss.PartyID) (int, error) {
+	return p.store.Count(poolIndex(owner.Id, pub, keyEpoch, partyIDs(signers)))
+}
+
+// ----- //
+
+// NewFileStore returns a FileStore rooted at `dir`, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(index, id string, entry []byte) error {
+	dir := filepath.Join(s.dir, index)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, id+consumedExt)); err == nil {
+		return ErrPresignExists
+	}
+	tmp, err := ioutil.TempFile(dir, id+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(entry); err == nil {
+		err = tmp.Sync()
+	}
+	if cErr := tmp.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return err
+	}
+	// unlike a rename, a link fails rather than replacing an entry that is already there
+	if err = os.Link(tmp.Name(), filepath.Join(dir, id+unusedExt)); err != nil {
+		if os.IsExist(err) {
+			return ErrPresignExists
+		}
+		return err
+	}
+	return syncDir(dir)
+}
+
+func (s *FileStore) Take(index string) ([]byte, error) {
+	dir := filepath.Join(s.dir, index)
+	ids, err := s.unused(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		entry, err := s.take(dir, id)
+		if os.IsNotExist(err) {
+			continue // taken by another process
+		}
+		return entry, err
+	}
+	return nil, ErrPoolEmpty
+}
+
+func (s *FileStore) TakeID(index, id string) ([]byte, error) {
+	entry, err := s.take(filepath.Join(s.dir, index), id)
+	if os.IsNotExist(err) {
+		return nil, ErrPresignUnknown
+	}
+	return entry, err
+}
+
+// take claims the entry `id` in `dir` by renaming it, and returns an error satisfying os.IsNotExist if it is gone
+func (s *FileStore) take(dir, id string) ([]byte, error) {
+	consumed := filepath.Join(dir, id+consumedExt)
+	if err := os.Rename(filepath.Join(dir, id+unusedExt), consumed); err != nil {
+		return nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		return nil, err
+	}
+	entry, err := ioutil.ReadFile(consumed)
+	if err != nil {
+		return nil, err
+	}
+	// keep the name as a tombstone but drop the secret material
+	if err = os.Truncate(consumed, 0); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *FileStore) Count(index string) (int, error) {
+	ids, err := s.unused(filepath.Join(s.dir, index))
+	return len(ids), err
+}
+
+func (s *FileStore) unused(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ids := make([]string, 0, len(files))
+	for _, f := range files {
+		if name := f.Name(); strings.HasSuffix(name, unusedExt) {
+			ids = append(ids, strings.TrimSuffix(name, unusedExt))
+		}
+	}
+	return ids, nil
+}
+
+// ----- //
+
+func poolIndex(owner string, pub *crypto.ECPoint, keyEpoch uint64, signers []string) string {
+	if pub == nil {
+		return poolDigest()
+	}
+	sorted := append([]string{}, signers...)
+	sort.Strings(sorted)
+	epoch := make([]byte, 8)
+	binary.BigEndian.PutUint64(epoch, keyEpoch)
+	parts := [][]byte{[]byte(owner), pub.X().Bytes(), pub.Y().Bytes(), epoch}
+	for _, id := range sorted {
+		parts = append(parts, []byte(id))
+	}
+	return poolDigest(parts...)
+}
+
+// poolDigest hashes length-prefixed parts so that different splits of the same bytes do not collide
+func poolDigest(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = fmt.Fprintf(h, "%d:", len(part))
+		_, _ = h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func partyIDs(parties []*tss.PartyID) []string {
+	ids := make([]string, len(parties))
+	for j, P := range parties {
+		ids[j] = P.Id
+	}
+	return ids
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
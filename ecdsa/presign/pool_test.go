@@ -0,0 +1,181 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/envelope"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func TestPoolTakeOnce(t *testing.T) {
+	presigns, signPIDs, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	data := &presigns[0]
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	pool := NewPool(store, secret)
+	assert.NoError(t, pool.Add(data))
+	assert.ErrorIs(t, pool.Add(data), ErrPresignExists)
+
+	n, err := pool.Count(data.ECDSAPub, data.KeyEpoch, signPIDs, signPIDs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = pool.Count(data.ECDSAPub, data.KeyEpoch, signPIDs[1:], signPIDs[0])
+	assert.NoError(t, err)
+	assert.Zero(t, n, "a different signer set must not see the entry")
+
+	// a second pool over the same directory stands in for a restarted process
+	store2, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	pool2 := NewPool(store2, secret)
+	got, err := pool2.Take(data.ECDSAPub, data.KeyEpoch, signPIDs, signPIDs[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, data.KI, got.KI)
+		assert.Equal(t, data.RSigmaI, got.RSigmaI)
+	}
+	_, err = pool.Take(data.ECDSAPub, data.KeyEpoch, signPIDs, signPIDs[0])
+	assert.ErrorIs(t, err, ErrPoolEmpty)
+	assert.ErrorIs(t, pool.Add(data), ErrPresignExists, "a consumed presignature must not be added again")
+}
+
+func TestPoolConcurrentTake(t *testing.T) {
+	presigns, signPIDs, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, NewPool(store, secret).Add(&presigns[0]))
+
+	var taken int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store, _ := NewFileStore(dir)
+			if _, err := NewPool(store, secret).Take(presigns[0].ECDSAPub, presigns[0].KeyEpoch, signPIDs, signPIDs[0]); err == nil {
+				atomic.AddInt32(&taken, 1)
+			} else {
+				assert.ErrorIs(t, err, ErrPoolEmpty)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), taken)
+}
+
+func TestPoolAfterRefresh(t *testing.T) {
+	presigns, signPIDs, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	pool := NewPool(store, secret)
+
+	stale := presigns[0]
+	assert.NoError(t, pool.Add(&stale))
+
+	// after a refresh the key is at the next epoch, so the presignature of the old one must not be handed out
+	keyEpoch := stale.KeyEpoch + 1
+	n, err := pool.Count(stale.ECDSAPub, keyEpoch, signPIDs, signPIDs[0])
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	_, err = pool.Take(stale.ECDSAPub, keyEpoch, signPIDs, signPIDs[0])
+	assert.ErrorIs(t, err, ErrPoolEmpty)
+
+	// a presignature made after the refresh is handed out, and the stale one stays unused under the old epoch
+	fresh := withBigR(presigns[0], 2)
+	fresh.KeyEpoch = keyEpoch
+	assert.NoError(t, pool.Add(fresh))
+	got, err := pool.Take(stale.ECDSAPub, keyEpoch, signPIDs, signPIDs[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, keyEpoch, got.KeyEpoch)
+		assert.Equal(t, PoolID(fresh), PoolID(got))
+	}
+	n, err = pool.Count(stale.ECDSAPub, stale.KeyEpoch, signPIDs, signPIDs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestPoolSharedStore(t *testing.T) {
+	presigns, signPIDs, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	pool := NewPool(store, secret)
+
+	// every party of a presign session holds a presignature with the same R, so the same id
+	for j := range presigns {
+		assert.NoError(t, pool.Add(&presigns[j]), "party %d must be able to add to a shared store", j)
+	}
+	id := PoolID(&presigns[0])
+	for j, Pj := range signPIDs {
+		assert.Equal(t, id, PoolID(&presigns[j]))
+		n, err := pool.Count(presigns[j].ECDSAPub, presigns[j].KeyEpoch, signPIDs, Pj)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	for j, Pj := range signPIDs {
+		got, err := pool.TakeByID(presigns[j].ECDSAPub, presigns[j].KeyEpoch, signPIDs, Pj, id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, presigns[j].PartyId, got.PartyId, "a party must only take its own presignature")
+			assert.Equal(t, presigns[j].KI, got.KI)
+		}
+	}
+}
+
+func TestPoolTakeByID(t *testing.T) {
+	presigns, signPIDs, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	pool := NewPool(store, secret)
+
+	first, second := &presigns[0], withBigR(presigns[0], 2)
+	assert.NoError(t, pool.Add(first))
+	assert.NoError(t, pool.Add(second))
+	pub, keyEpoch, owner := first.ECDSAPub, first.KeyEpoch, signPIDs[0]
+
+	// the presignature announced by the coordinator is taken, whichever was added first
+	got, err := pool.TakeByID(pub, keyEpoch, signPIDs, owner, PoolID(second))
+	if assert.NoError(t, err) {
+		assert.Equal(t, PoolID(second), PoolID(got))
+	}
+	_, err = pool.TakeByID(pub, keyEpoch, signPIDs, owner, PoolID(second))
+	assert.ErrorIs(t, err, ErrPresignUnknown, "a consumed presignature must not be taken again")
+	_, err = pool.TakeByID(pub, keyEpoch, signPIDs, owner, PoolID(withBigR(presigns[0], 3)))
+	assert.ErrorIs(t, err, ErrPresignUnknown)
+	_, err = pool.TakeByID(pub, keyEpoch, signPIDs, signPIDs[1], PoolID(first))
+	assert.ErrorIs(t, err, ErrPresignUnknown, "another party must not take the presignature")
+
+	got, err = pool.Take(pub, keyEpoch, signPIDs, owner)
+	if assert.NoError(t, err) {
+		assert.Equal(t, PoolID(first), PoolID(got))
+	}
+}
+
+// withBigR returns a copy of `data` with R = k*G, standing in for a presignature of another session
+func withBigR(data LocalPresignData, k int64) *LocalPresignData {
+	data.BigR = crypto.ScalarBaseMult(tss.EC("ecdsa"), big.NewInt(k)).ToProtobufPoint()
+	return &data
+}
@@ -42,20 +42,29 @@ func (round *round1) Start() *tss.Error {
 	i := Pi.Index
 	round.ok[i] = true
 
-	gammaI := common.GetRandomPositiveInt(tss.EC("ecdsa").Params().N)
-	kI := common.GetRandomPositiveInt(tss.EC("ecdsa").Params().N)
+	gammaI := common.GetRandomPositiveInt(round.EC().Params().N)
+	kI := common.GetRandomPositiveInt(round.EC().Params().N)
 	round.temp.gammaI = gammaI
 	round.temp.r5AbortData.GammaI = gammaI.Bytes()
 
-	gammaIG := crypto.ScalarBaseMult(tss.EC("ecdsa"), gammaI)
+	gammaIG := crypto.ScalarBaseMult(round.EC(), gammaI)
 	round.temp.gammaIG = gammaIG
 
 	cmt := commitments.NewHashCommitment(gammaIG.X(), gammaIG.Y())
 	round.temp.deCommit = cmt.D
 
-	// MtA round 1
+	// MtA round 1; we own this Paillier key so the encryption can use the CRT unless the randomness was precomputed
 	paiPK := round.key.PaillierPKs[i]
-	cA, rA, err := paiPK.EncryptAndReturnRandomness(kI)
+	pool := round.temp.randomness.Pool(paiPK)
+	var cA, rA *big.Int
+	var err error
+	if pool != nil {
+		rnd := pool.Take()
+		cA, err = paiPK.EncryptWithRandomness(kI, rnd)
+		rA = rnd.R
+	} else {
+		cA, rA, err = round.key.PaillierSK.EncryptAndReturnRandomness(kI)
+	}
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -74,7 +83,7 @@ func (round *round1) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		pi, err := mta.AliceInit("ecdsa", paiPK, kI, cA, rA, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j])
+		pi, err := mta.AliceInit(round.Curve(), paiPK, kI, cA, rA, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], pool)
 		if err != nil {
 			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
 		}
@@ -131,7 +140,20 @@ func (round *round1) prepare() error {
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
-	if wI, bigWs, err := PrepareForPresigning(i, len(ks), xi, ks, bigXs); err != nil {
+	if round.key.ECDSAPub == nil || round.key.ECDSAPub.Curve() != round.EC() {
+		return fmt.Errorf("the key is not on the %s curve set in the parameters", round.Curve())
+	}
+	if round.key.PaillierSK == nil || round.key.PaillierPKs[i] == nil || round.key.PaillierSK.N.Cmp(round.key.PaillierPKs[i].N) != 0 {
+		return errors.New("the Paillier secret key does not match PaillierPKs for this party")
+	}
+	// a key that was unmarshalled without LoadLocalPartySaveData has no CRT values; derive them once for this run on
+	// a copy, as the save data may be shared with other parties
+	sk := *round.key.PaillierSK
+	if err := sk.Precompute(); err != nil {
+		return err
+	}
+	round.key.PaillierSK = &sk
+	if wI, bigWs, err := PrepareForPresigning(round.EC(), i, len(ks), xi, ks, bigXs); err != nil {
 		return err
 	} else {
 		round.temp.wI = wI
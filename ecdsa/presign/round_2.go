@@ -44,7 +44,7 @@ func (round *round2) Start() *tss.Error {
 				return
 			}
 			betaJI, c1JI, _, pi1JI, err := mta.BobMid(
-				"ecdsa",
+				round.Curve(),
 				round.key.PaillierPKs[j],
 				rangeProofAliceJ,
 				round.temp.gammaI,
@@ -54,7 +54,8 @@ func (round *round2) Start() *tss.Error {
 				round.key.H2j[j],
 				round.key.NTildej[i],
 				round.key.H1j[i],
-				round.key.H2j[i])
+				round.key.H2j[i],
+				round.temp.randomness.Pool(round.key.PaillierPKs[j]))
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
 				return
@@ -75,7 +76,7 @@ func (round *round2) Start() *tss.Error {
 				return
 			}
 			vJI, c2JI, pi2JI, err := mta.BobMidWC(
-				"ecdsa",
+				round.Curve(),
 				round.key.PaillierPKs[j],
 				rangeProofAliceJ,
 				round.temp.wI,
@@ -86,7 +87,8 @@ func (round *round2) Start() *tss.Error {
 				round.key.NTildej[i],
 				round.key.H1j[i],
 				round.key.H2j[i],
-				round.temp.bigWs[i])
+				round.temp.bigWs[i],
+				round.temp.randomness.Pool(round.key.PaillierPKs[j]))
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
 				return
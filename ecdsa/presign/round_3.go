@@ -53,7 +53,7 @@ func (round *round3) Start() *tss.Error {
 				return
 			}
 			alphaIJ, err := mta.AliceEnd(
-				"ecdsa",
+				round.Curve(),
 				round.key.PaillierPKs[i],
 				proofBob,
 				round.key.H1j[i],
@@ -73,13 +73,13 @@ func (round *round3) Start() *tss.Error {
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
 			r2msg := round.temp.presignRound2Messages[j].Content().(*PresignRound2Message)
-			proofBobWC, err := r2msg.UnmarshalProofBobWC()
+			proofBobWC, err := r2msg.UnmarshalProofBobWC(round.Curve())
 			if err != nil {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalProofBobWC failed"), Pj)
 				return
 			}
 			muIJ, muIJRec, muIJRand, err := mta.AliceEndWC(
-				"ecdsa",
+				round.Curve(),
 				round.key.PaillierPKs[i],
 				proofBobWC,
 				round.temp.bigWs[j],
@@ -113,7 +113,7 @@ func (round *round3) Start() *tss.Error {
 	round.temp.r7AbortData.MuIJ = common.BigIntsToBytes(muIJRecs)
 	round.temp.r7AbortData.MuRandIJ = common.BigIntsToBytes(muRandIJ)
 
-	q := tss.EC("ecdsa").Params().N
+	q := round.EC().Params().N
 	modN := common.ModInt(q)
 
 	kI := new(big.Int).SetBytes(round.temp.KI)
@@ -139,18 +139,18 @@ func (round *round3) Start() *tss.Error {
 
 	// gg20: calculate T_i = g^sigma_i h^l_i
 	lI := common.GetRandomPositiveInt(q)
-	h, err := crypto.ECBasePoint2(tss.EC("ecdsa"))
+	h, err := crypto.ECBasePoint2(round.EC())
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
 	hLI := h.ScalarMult(lI)
-	gSigmaI := crypto.ScalarBaseMult(tss.EC("ecdsa"), sigmaI)
+	gSigmaI := crypto.ScalarBaseMult(round.EC(), sigmaI)
 	TI, err := gSigmaI.Add(hLI)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
-	// gg20: generate the ZK proof of T_i, verified in ValidateBasic for the round 3 message
-	tProof, err := zkp.NewTProof("ecdsa", TI, h, sigmaI, lI)
+	// gg20: generate the ZK proof of T_i, verified by the other parties in round 5 with VerifyTProof
+	tProof, err := zkp.NewTProof(round.Curve(), TI, h, sigmaI, lI)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -30,7 +30,7 @@ func (round *round5) Start() *tss.Error {
 	Pi := round.PartyID()
 	i := Pi.Index
 
-	modN := common.ModInt(tss.EC("ecdsa").Params().N)
+	modN := common.ModInt(round.EC().Params().N)
 
 	bigR := round.temp.gammaIG
 	deltaI := *round.temp.deltaI
@@ -44,6 +44,11 @@ func (round *round5) Start() *tss.Error {
 		r3msg := round.temp.presignRound3Messages[j].Content().(*PresignRound3Message)
 		r4msg := round.temp.presignRound4Messages[j].Content().(*PresignRound4Message)
 
+		// verify the TProof of Pj from round 3
+		if !r3msg.VerifyTProof(round.Curve()) {
+			return round.WrapError(errors.New("TProof verify failed"), Pj)
+		}
+
 		// calculating Big R
 		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
 		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
@@ -51,7 +56,7 @@ func (round *round5) Start() *tss.Error {
 		if !ok || len(bigGammaJ) != 2 {
 			return round.WrapError(errors.New("commitment verify failed"), Pj)
 		}
-		bigGammaJPoint, err := crypto.NewECPoint(tss.EC("ecdsa"), bigGammaJ[0], bigGammaJ[1])
+		bigGammaJPoint, err := crypto.NewECPoint(round.EC(), bigGammaJ[0], bigGammaJ[1])
 		if err != nil {
 			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
 		}
@@ -97,7 +102,7 @@ func (round *round5) Start() *tss.Error {
 		X:  kI,
 		R:  round.temp.rAKI,
 	}
-	pdlWSlackPf := zkp.NewPDLwSlackProof("ecdsa", pdlWSlackWitness, pdlWSlackStatement)
+	pdlWSlackPf := zkp.NewPDLwSlackProof(round.Curve(), pdlWSlackWitness, pdlWSlackStatement)
 
 	r5msg := NewPresignRound5Message(Pi, bigRBarI, &pdlWSlackPf)
 	round.temp.presignRound5Messages[i] = r5msg
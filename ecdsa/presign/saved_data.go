@@ -2,9 +2,12 @@ package presign
 
 import (
 	"encoding/json"
+	"io"
+	"io/ioutil"
 
 	common "github.com/sisu-network/tss-lib/common"
 	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/envelope"
 )
 
 type (
@@ -21,9 +24,47 @@ type (
 		BigSJ    map[string]*common.ECPoint
 
 		ECDSAPub *crypto.ECPoint // y
+		// the epoch of the key shares that made this presignature; see keygen.LocalPartySaveData.Epoch
+		KeyEpoch uint64
 	}
 )
 
 func (d *LocalPresignData) Marshall() ([]byte, error) {
 	return json.Marshal(d)
 }
+
+// PresignDataContentType identifies presign data inside an encrypted envelope
+const PresignDataContentType = "ecdsa-presign-data"
+
+// SaveLocalPresignData encrypts `data` under `secret` and writes it to `w` as a versioned envelope.
+// Use this instead of Marshall(), as the presign data holds k_i and sigma_i.
+func SaveLocalPresignData(w io.Writer, data *LocalPresignData, secret envelope.Secret) error {
+	bz, err := data.Marshall()
+	if err != nil {
+		return err
+	}
+	sealed, err := envelope.Seal(PresignDataContentType, bz, secret)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(sealed)
+	return err
+}
+
+// LoadLocalPresignData reads an envelope written by SaveLocalPresignData and decrypts it with `secret`.
+// It refuses unknown envelope versions and data that was tampered with.
+func LoadLocalPresignData(r io.Reader, secret envelope.Secret) (*LocalPresignData, error) {
+	sealed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := envelope.Open(PresignDataContentType, sealed, secret)
+	if err != nil {
+		return nil, err
+	}
+	data := new(LocalPresignData)
+	if err = json.Unmarshal(bz, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
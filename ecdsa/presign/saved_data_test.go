@@ -0,0 +1,53 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/crypto/envelope"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+)
+
+func TestSaveLoadLocalPresignData(t *testing.T) {
+	presigns, _, err := LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+	data := &presigns[0]
+	secret := envelope.Key(bytes.Repeat([]byte{7}, envelope.KeyLen))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, SaveLocalPresignData(buf, data, secret))
+	sealed := buf.Bytes()
+	assert.NotContains(t, string(sealed), base64.StdEncoding.EncodeToString(data.KI))
+
+	loaded, err := LoadLocalPresignData(bytes.NewReader(sealed), secret)
+	if assert.NoError(t, err) {
+		assert.Equal(t, data.PartyId, loaded.PartyId)
+		assert.Equal(t, data.KI, loaded.KI)
+		assert.Equal(t, data.RSigmaI, loaded.RSigmaI)
+		assert.Equal(t, PoolID(data), PoolID(loaded))
+		assert.True(t, data.ECDSAPub.Equals(loaded.ECDSAPub))
+		assert.Equal(t, data.KeyEpoch, loaded.KeyEpoch)
+	}
+
+	_, err = LoadLocalPresignData(bytes.NewReader(sealed), envelope.Key(bytes.Repeat([]byte{8}, envelope.KeyLen)))
+	assert.ErrorIs(t, err, envelope.ErrDecryptionFailed)
+	_, err = LoadLocalPresignData(bytes.NewReader(sealed), envelope.Passphrase([]byte("passphrase")))
+	assert.ErrorIs(t, err, envelope.ErrWrongSecretKind)
+
+	// an envelope of another kind of data must not load as presign data, even under the right secret
+	bz, err := data.Marshall()
+	assert.NoError(t, err)
+	other, err := envelope.Seal(keygen.SaveDataContentType, bz, secret)
+	assert.NoError(t, err)
+	_, err = LoadLocalPresignData(bytes.NewReader(other), secret)
+	assert.ErrorIs(t, err, envelope.ErrWrongContentType)
+}
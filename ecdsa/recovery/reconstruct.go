@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package recovery rebuilds the full ECDSA private key from the keygen save data of t+1 parties.
+// It is meant for offline disaster recovery only and is intentionally kept apart from the online protocols:
+// once the key has been reconstructed it exists in one place and the threshold property is lost.
+package recovery
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	// PrivateKeyBytesLen is the length of a private key encoded by MarshalPrivateKey
+	PrivateKeyBytesLen = 32
+)
+
+// ReconstructKey rebuilds the ECDSA private key shared by `keys` using Lagrange interpolation over the Xi shares.
+// Each share is checked against its own BigXj before it is used and shares that fail the check are dropped.
+// An error is returned if fewer than threshold+1 distinct valid shares remain or the reconstructed key does not
+// match the ECDSAPub in the save data.
+func ReconstructKey(threshold int, keys []keygen.LocalPartySaveData) (*ecdsa.PrivateKey, error) {
+	if threshold < 1 {
+		return nil, errors.New("ReconstructKey: threshold must be at least 1")
+	}
+	if len(keys) == 0 || keys[0].ECDSAPub == nil {
+		return nil, errors.New("ReconstructKey: no save data with an ECDSAPub was supplied")
+	}
+	pub := keys[0].ECDSAPub
+	ec := pub.Curve()
+	curve := tss.GetCurveScheme(ec)
+
+	var invalid error
+	seen := make(map[string]struct{}, len(keys))
+	shares := make(vss.Shares, 0, len(keys))
+	for i, key := range keys {
+		if key.ECDSAPub == nil || !key.ECDSAPub.Equals(pub) {
+			return nil, fmt.Errorf("ReconstructKey: save data %d belongs to a different key", i)
+		}
+		if err := verifyShare(key); err != nil {
+			common.Logger.Warnf("ReconstructKey: dropping share from save data %d: %v", i, err)
+			invalid = multierror.Append(invalid, fmt.Errorf("save data %d: %v", i, err))
+			continue
+		}
+		id := key.ShareID.String()
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		shares = append(shares, &vss.Share{
+			Threshold: threshold,
+			ID:        key.ShareID,
+			Share:     key.Xi,
+		})
+	}
+	if len(shares) < threshold+1 {
+		err := fmt.Errorf("ReconstructKey: %d valid shares supplied but t+1=%d are required: %w",
+			len(shares), threshold+1, vss.ErrNumSharesBelowThreshold)
+		if invalid != nil {
+			err = multierror.Append(err, invalid)
+		}
+		return nil, err
+	}
+
+	secret, err := shares.ReConstruct(curve)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Sign() == 0 || !crypto.ScalarBaseMult(ec, secret).Equals(pub) {
+		return nil, errors.New("ReconstructKey: the reconstructed key does not match the ECDSAPub in the save data")
+	}
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub.ToECDSAPubKey(),
+		D:         secret,
+	}, nil
+}
+
+// MarshalPrivateKey encodes the private scalar as a 32-byte big-endian integer, the raw form accepted by
+// btcec.PrivKeyFromBytes and most wallets.
+func MarshalPrivateKey(sk *ecdsa.PrivateKey) []byte {
+	bz := make([]byte, PrivateKeyBytesLen)
+	return sk.D.FillBytes(bz)
+}
+
+// verifyShare checks that Xi*G matches the BigXj recorded for ShareID in the same save data.
+func verifyShare(key keygen.LocalPartySaveData) error {
+	if key.Xi == nil || key.ShareID == nil {
+		return errors.New("missing Xi or ShareID")
+	}
+	idx := -1
+	for j, kj := range key.Ks {
+		if kj != nil && kj.Cmp(key.ShareID) == 0 {
+			idx = j
+			break
+		}
+	}
+	if idx < 0 || idx >= len(key.BigXj) || key.BigXj[idx] == nil {
+		return errors.New("no BigXj was found for ShareID")
+	}
+	ec := key.ECDSAPub.Curve()
+	if key.Xi.Sign() <= 0 || key.Xi.Cmp(ec.Params().N) >= 0 {
+		return errors.New("Xi is out of range")
+	}
+	if !crypto.ScalarBaseMult(ec, new(big.Int).Set(key.Xi)).Equals(key.BigXj[idx]) {
+		return errors.New("Xi*G did not match BigXj")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/test"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func TestReconstructKey(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	sk, err := ReconstructKey(testThreshold, keys[:testThreshold+1])
+	assert.NoError(t, err)
+	assert.Equal(t, keys[0].ECDSAPub.X(), sk.X)
+	assert.Equal(t, keys[0].ECDSAPub.Y(), sk.Y)
+
+	// a different t+1 subset recovers the same key
+	sk2, err := ReconstructKey(testThreshold, keys[testParticipants-testThreshold-1:])
+	assert.NoError(t, err)
+	assert.Equal(t, sk.D, sk2.D)
+
+	// the recovered key produces signatures that standard verifiers accept
+	digest := sha256.Sum256([]byte("recovery"))
+	r, s, err := ecdsa.Sign(rand.Reader, sk, digest[:])
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.Verify(&sk.PublicKey, digest[:], r, s))
+
+	bz := MarshalPrivateKey(sk)
+	assert.Len(t, bz, PrivateKeyBytesLen)
+	btcSK, btcPK := btcec.PrivKeyFromBytes(btcec.S256(), bz)
+	assert.Equal(t, sk.D, btcSK.D)
+	assert.True(t, btcPK.IsEqual((*btcec.PublicKey)(&sk.PublicKey)))
+}
+
+func TestReconstructKeyBelowThreshold(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	_, err = ReconstructKey(testThreshold, keys[:testThreshold])
+	assert.Error(t, err)
+
+	// duplicated shares are only counted once
+	dup := append(keys[:testThreshold:testThreshold], keys[0])
+	_, err = ReconstructKey(testThreshold, dup)
+	assert.Error(t, err)
+}
+
+func TestReconstructKeyTamperedShare(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	keys[0].Xi = new(big.Int).Add(keys[0].Xi, big.NewInt(1))
+	_, err = ReconstructKey(testThreshold, keys[:testThreshold+1])
+	assert.Error(t, err, "a tampered share must not count towards t+1")
+
+	// the tampered share is dropped when enough valid shares remain
+	sk, err := ReconstructKey(testThreshold, keys[:testThreshold+2])
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1].ECDSAPub.X(), sk.X)
+}
@@ -0,0 +1,196 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/dlnp"
+	"github.com/sisu-network/tss-lib/crypto/paillier"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-refresh.pb.go
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RefreshRound1Message)(nil),
+		(*RefreshRound2Message1)(nil),
+		(*RefreshRound2Message2)(nil),
+	}
+)
+
+// ----- //
+
+// NewRefreshRound1Message builds the round 1 broadcast. `preParams` is nil when the sender keeps its current
+// Paillier key and NTilde; otherwise the new values are sent along with the proofs that they are well-formed.
+func NewRefreshRound1Message(
+	from *tss.PartyID,
+	epoch uint64,
+	ct cmt.HashCommitment,
+	preParams *keygen.LocalPreParams,
+	dlnProof1, dlnProof2 *dlnp.Proof,
+	paillierProof paillier.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound1Message{
+		Commitment: ct.Bytes(),
+		Epoch:      epoch,
+	}
+	if preParams != nil {
+		dlnProof1Bz, err := dlnProof1.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dlnProof2Bz, err := dlnProof2.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		pfBzs := make([][]byte, len(paillierProof))
+		for i := range pfBzs {
+			if paillierProof[i] == nil {
+				continue
+			}
+			pfBzs[i] = paillierProof[i].Bytes()
+		}
+		content.PaillierN = preParams.PaillierSK.N.Bytes()
+		content.NTilde = preParams.NTildei.Bytes()
+		content.H1 = preParams.H1i.Bytes()
+		content.H2 = preParams.H2i.Bytes()
+		content.Dlnproof_1 = dlnProof1Bz
+		content.Dlnproof_2 = dlnProof2Bz
+		content.PaillierProof = pfBzs
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RefreshRound1Message) ValidateBasic() bool {
+	if m == nil || !common.NonEmptyBytes(m.GetCommitment()) {
+		return false
+	}
+	if !m.RotatesPreParams() {
+		return len(m.GetNTilde()) == 0 &&
+			len(m.GetH1()) == 0 &&
+			len(m.GetH2()) == 0 &&
+			len(m.GetDlnproof_1()) == 0 &&
+			len(m.GetDlnproof_2()) == 0 &&
+			len(m.GetPaillierProof()) == 0
+	}
+	return common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+}
+
+// RotatesPreParams reports whether the sender is replacing its Paillier key and NTilde in this refresh.
+func (m *RefreshRound1Message) RotatesPreParams() bool {
+	return len(m.GetPaillierN()) > 0
+}
+
+func (m *RefreshRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *RefreshRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RefreshRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RefreshRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RefreshRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof1() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_1())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof2() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_2())
+}
+
+func (m *RefreshRound1Message) UnmarshalPaillierProof() paillier.Proof {
+	var pf paillier.Proof
+	proofBzs := m.GetPaillierProof()
+	for i := range pf {
+		pf[i] = new(big.Int).SetBytes(proofBzs[i])
+	}
+	return pf
+}
+
+// ----- //
+
+func NewRefreshRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RefreshRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RefreshRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+// ----- //
+
+func NewRefreshRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &RefreshRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RefreshRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
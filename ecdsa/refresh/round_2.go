@@ -0,0 +1,170 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify the dln and paillier proofs of rotated pre-params, store r1 message pieces
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(Ps))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(Ps))
+	paiProofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.rfRound1Messages {
+		round.temp.KGCs[j] = msg.Content().(*RefreshRound1Message).UnmarshalCommitment()
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*RefreshRound1Message)
+		if r1msg.GetEpoch() != round.input.Epoch {
+			return round.WrapError(fmt.Errorf("party is refreshing key epoch %d but ours is %d",
+				r1msg.GetEpoch(), round.input.Epoch), msg.GetFrom())
+		}
+		if !r1msg.RotatesPreParams() {
+			continue
+		}
+		H1j, H2j, NTildej, paillierPKj :=
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		wg.Add(3)
+		go func(j int, msg tss.ParsedMessage, r1msg *RefreshRound1Message, H1j, H2j, NTildej *big.Int) {
+			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+				dlnProof1FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+		go func(j int, msg tss.ParsedMessage, r1msg *RefreshRound1Message, H1j, H2j, NTildej *big.Int) {
+			if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+				dlnProof2FailCulprits[j] = msg.GetFrom()
+			}
+			wg.Done()
+		}(j, msg, r1msg, H1j, H2j, NTildej)
+		go func(j int, msg tss.ParsedMessage, r1msg *RefreshRound1Message, N *big.Int) {
+			if ok, err := r1msg.UnmarshalPaillierProof().Verify(N, msg.GetFrom().KeyInt(), round.input.ECDSAPub); err != nil || !ok {
+				paiProofFailCulprits[j] = msg.GetFrom()
+				common.Logger.Warnf("paillier verify failed for party %s", msg.GetFrom(), err)
+			}
+			wg.Done()
+		}(j, msg, r1msg, paillierPKj.N)
+		round.temp.newPaillierPKs[j] = paillierPKj
+		round.temp.newNTildej[j] = NTildej
+		round.temp.newH1j[j], round.temp.newH2j[j] = H1j, H2j
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+	for _, culprit := range paiProofFailCulprits {
+		if culprit != nil {
+			return round.WrapError(errors.New("paillier verify failed"), culprit)
+		}
+	}
+
+	// 2. ensure uniqueness of h1j, h2j across the committee after rotation
+	h1H2Map := make(map[string]struct{}, len(Ps)*2)
+	for j, Pj := range Ps {
+		H1j, H2j := round.input.H1j[j], round.input.H2j[j]
+		if round.temp.newNTildej[j] != nil {
+			H1j, H2j = round.temp.newH1j[j], round.temp.newH2j[j]
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+	}
+
+	// 3. p2p send share ij to Pj
+	shares := round.temp.shares
+	for j, Pj := range Ps {
+		r2msg1 := NewRefreshRound2Message1(Pj, round.PartyID(), shares[j])
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.rfRound2Message1s[j] = r2msg1
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// 4. BROADCAST de-commitments of the zero poly*G
+	r2msg2 := NewRefreshRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
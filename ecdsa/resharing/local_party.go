@@ -64,6 +64,9 @@ type (
 // The `key` is read from and/or written to depending on whether this party is part of the old or the new committee.
 // You may optionally generate and set the LocalPreParams if you would like to use pre-generated safe primes and Paillier secret.
 // (This is similar to providing the `optionalPreParams` to `keygen.LocalParty`).
+// A party that is in both committees runs a single LocalParty with one PartyID and its old `key`; it plays both roles
+// and keeps its own share of the new key instead of sending it. It keeps the LocalPreParams of its old
+// `key`, so no new safe primes or Paillier keys are generated.
 func NewLocalParty(
 	params *tss.ReSharingParameters,
 	key keygen.LocalPartySaveData,
@@ -122,17 +125,10 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
-	// check that the message's "from index" will fit into the array
-	var maxFromIdx int
-	switch msg.Content().(type) {
-	case *DGRound2Message1, *DGRound2Message2, *DGRound4Message:
-		maxFromIdx = len(p.params.NewParties().IDs()) - 1
-	default:
-		maxFromIdx = len(p.params.OldParties().IDs()) - 1
-	}
-	if maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	// check that the sender is a member of the committee that sends this type of message
+	if p.senderIndex(msg) < 0 {
+		return false, p.WrapError(fmt.Errorf("received msg from a party that is not in the sending committee: %s",
+			msg.GetFrom()), msg.GetFrom())
 	}
 	return true, nil
 }
@@ -142,7 +138,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
-	fromPIdx := msg.GetFrom().Index
+	fromPIdx := p.senderIndex(msg)
 
 	// switch/case is necessary to store any messages beyond current round
 	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
@@ -166,6 +162,17 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	return true, nil
 }
 
+// senderIndex returns the index of the sender of `msg` in the committee that sends messages of its type, or -1. The
+// index is looked up by key because a party in both committees has a different index in each.
+func (p *LocalParty) senderIndex(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *DGRound2Message1, *DGRound2Message2, *DGRound4Message:
+		return p.params.NewParties().IDs().IndexOf(msg.GetFrom().KeyInt())
+	default:
+		return p.params.OldParties().IDs().IndexOf(msg.GetFrom().KeyInt())
+	}
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -8,6 +8,7 @@ package resharing_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -22,6 +23,7 @@ import (
 	"github.com/sisu-network/tss-lib/crypto"
 	"github.com/sisu-network/tss-lib/ecdsa/keygen"
 	"github.com/sisu-network/tss-lib/ecdsa/presign"
+	"github.com/sisu-network/tss-lib/ecdsa/recovery"
 	. "github.com/sisu-network/tss-lib/ecdsa/resharing"
 	"github.com/sisu-network/tss-lib/ecdsa/signing"
 	"github.com/sisu-network/tss-lib/test"
@@ -154,6 +156,7 @@ func TestE2EConcurrent(t *testing.T) {
 					gXj := crypto.ScalarBaseMult(tss.EC("ecdsa"), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+					assert.Equal(t, oldKeys[0].Epoch+1, key.Epoch, "resharing must advance the key epoch")
 				}
 
 				// more verification of signing is implemented within local_party_test.go of keygen package
@@ -242,7 +245,7 @@ signing:
 
 	for j, signPID := range signPIDs {
 		params := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), newThreshold)
-		P := signing.NewLocalParty(big.NewInt(42), params, *presignOutputs[j], signOutCh, signEndCh).(*signing.LocalParty)
+		P := signing.NewLocalPartyWithKey(big.NewInt(42), params, signKeys[j], *presignOutputs[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
 			if err := P.Start(); err != nil {
@@ -302,3 +305,271 @@ signing:
 		}
 	}
 }
+
+func TestE2EAddParty(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures; every old party keeps its place and one party is added
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testThreshold + 3)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	pub := oldKeys[0].ECDSAPub
+	oldPaillierNs := make([]*big.Int, len(oldKeys))
+	for j, key := range oldKeys {
+		oldPaillierNs[j] = key.PaillierSK.N
+	}
+
+	// the new committee shares the PartyIDs of the old one, so their Index is now the one in the new committee
+	added := tss.GenerateTestPartyIDs(1, len(oldPIDs))[0]
+	newPIDs := tss.SortPartyIDs(append(append(tss.UnSortedPartyIDs{}, oldPIDs...), added))
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+
+	errCh := make(chan *tss.Error, len(newPIDs))
+	outCh := make(chan tss.Message, len(newPIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(newPIDs))
+
+	parties := make([]*LocalParty, 0, len(newPIDs))
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, len(newPIDs), threshold)
+		key := keygen.NewLocalPartySaveData(len(newPIDs))
+		key.LocalPreParams = fixtures[len(oldPIDs)].LocalPreParams
+		if j := oldPIDs.IndexOf(pID.KeyInt()); 0 <= j {
+			assert.True(t, params.IsOldAndNewCommittee())
+			key = oldKeys[j]
+		}
+		parties = append(parties, NewLocalParty(params, key, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, 0, len(newPIDs))
+	for len(newKeys) < len(newPIDs) {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			// route by key: a party in both committees is listed once in each
+			delivered := make(map[string]bool, len(msg.GetTo()))
+			for _, destP := range msg.GetTo() {
+				key := destP.KeyInt().String()
+				if delivered[key] || key == msg.GetFrom().KeyInt().String() {
+					continue
+				}
+				delivered[key] = true
+				go test.SharedPartyUpdater(parties[newPIDs.IndexOf(destP.KeyInt())], msg, errCh)
+			}
+
+		case save := <-endCh:
+			newKeys = append(newKeys, save)
+		}
+	}
+
+	for _, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.True(t, key.ECDSAPub.Equals(pub), "the public key must be kept")
+		th, err := key.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, threshold, th)
+		if j := oldPIDs.IndexOf(key.ShareID); 0 <= j {
+			assert.Equal(t, oldPaillierNs[j], key.PaillierSK.N, "a party in both committees must keep its pre-params")
+		}
+	}
+	sk, err := recovery.ReconstructKey(threshold, newKeys[1:threshold+2])
+	if assert.NoError(t, err, "any t+1 new shares must give back the key") {
+		assert.Equal(t, pub.X(), sk.PublicKey.X)
+	}
+}
+
+func TestE2EP256(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: keygen on P-256 with t+1 parties, re-using the pre-params of the secp256k1 fixtures
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldPIDs := tss.GenerateTestPartyIDs(threshold + 1)
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	errCh := make(chan *tss.Error, testParticipants)
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	keygenEndCh := make(chan keygen.LocalPartySaveData, len(oldPIDs))
+	keygenParties := make([]tss.Party, 0, len(oldPIDs))
+	for j, pID := range oldPIDs {
+		params := tss.NewParameters(oldP2PCtx, pID, len(oldPIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		keygenParties = append(keygenParties, keygen.NewLocalParty(params, outCh, keygenEndCh, fixtures[j].LocalPreParams))
+	}
+	oldKeys := make([]keygen.LocalPartySaveData, len(oldPIDs))
+	if !runParties(t, keygenParties, outCh, errCh, func() {
+		for range oldPIDs {
+			save := <-keygenEndCh
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			oldKeys[index] = save
+		}
+	}) {
+		return
+	}
+	pub := oldKeys[0].ECDSAPub
+	assert.Equal(t, elliptic.P256(), pub.Curve())
+
+	// PHASE: reshare to a new committee of n parties
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	endCh := make(chan keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, len(newPIDs), threshold)
+		params.SetCurve(tss.EcdsaP256Scheme)
+		oldCommittee = append(oldCommittee, NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty))
+	}
+	newCommittee := make([]*LocalParty, 0, len(newPIDs))
+	for j, pID := range newPIDs {
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, len(newPIDs), threshold)
+		params.SetCurve(tss.EcdsaP256Scheme)
+		save := keygen.NewLocalPartySaveData(len(newPIDs))
+		save.LocalPreParams = fixtures[j].LocalPreParams
+		newCommittee = append(newCommittee, NewLocalParty(params, save, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range append(append([]*LocalParty{}, newCommittee...), oldCommittee...) {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(newPIDs))
+	for ended := 0; ended < len(oldPIDs)+len(newPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					go test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+
+		case save := <-endCh:
+			ended++
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoError(t, err)
+				newKeys[index] = save
+			}
+		}
+	}
+	for _, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.True(t, key.ECDSAPub.Equals(pub), "the public key must not change")
+	}
+
+	// PHASE: presign and sign with t+1 of the new parties
+	signPIDs := newPIDs[:threshold+1]
+	signP2PCtx := tss.NewPeerContext(signPIDs)
+	presignEndCh := make(chan *presign.LocalPresignData, len(signPIDs))
+	presignParties := make([]tss.Party, 0, len(signPIDs))
+	for j, pID := range signPIDs {
+		params := tss.NewParameters(signP2PCtx, pID, len(signPIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		presignParties = append(presignParties, presign.NewLocalParty(params, newKeys[j], outCh, presignEndCh))
+	}
+	presigns := make([]presign.LocalPresignData, len(signPIDs))
+	if !runParties(t, presignParties, outCh, errCh, func() {
+		for range signPIDs {
+			data := <-presignEndCh
+			for j, pID := range signPIDs {
+				if pID.Id == data.PartyId {
+					presigns[j] = *data
+				}
+			}
+		}
+	}) {
+		return
+	}
+
+	msg := common.GetRandomPositiveInt(elliptic.P256().Params().N)
+	signEndCh := make(chan *common.ECSignature, len(signPIDs))
+	signParties := make([]tss.Party, 0, len(signPIDs))
+	for j, pID := range signPIDs {
+		params := tss.NewParameters(signP2PCtx, pID, len(signPIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		signParties = append(signParties, signing.NewLocalPartyWithKey(msg, params, newKeys[j], presigns[j], outCh, signEndCh))
+	}
+	var sig *common.ECSignature
+	if !runParties(t, signParties, outCh, errCh, func() {
+		for range signPIDs {
+			sig = <-signEndCh
+		}
+	}) {
+		return
+	}
+
+	// the signature of the reshared key must verify with the standard library on P-256
+	pk := ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     pub.X(),
+		Y:     pub.Y(),
+	}
+	r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+	assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "P-256 ecdsa verify must pass")
+}
+
+// runParties starts the parties of a keygen, presign or signing and routes their messages until `collect` returns.
+// Every party is started before any message is routed, so `outCh` must be able to hold all of the first round's
+// messages. It returns false if a party reported an error.
+func runParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, collect func()) bool {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+			return false
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		collect()
+		close(done)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return false
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-done:
+			return true
+		}
+	}
+}
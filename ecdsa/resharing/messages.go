@@ -37,6 +37,7 @@ func NewDGRound1Message(
 	to []*tss.PartyID,
 	from *tss.PartyID,
 	ecdsaPub *crypto.ECPoint,
+	epoch uint64,
 	vct cmt.HashCommitment,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
@@ -48,6 +49,7 @@ func NewDGRound1Message(
 	content := &DGRound1Message{
 		EcdsaPub:    ecdsaPub.ToProtobufPoint(),
 		VCommitment: vct.Bytes(),
+		Epoch:       epoch,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -60,8 +62,8 @@ func (m *DGRound1Message) ValidateBasic() bool {
 		common.NonEmptyBytes(m.VCommitment)
 }
 
-func (m *DGRound1Message) UnmarshalECDSAPub() (*crypto.ECPoint, error) {
-	return crypto.NewECPointFromProtobuf("ecdsa", m.GetEcdsaPub())
+func (m *DGRound1Message) UnmarshalECDSAPub(curve string) (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(curve, m.GetEcdsaPub())
 }
 
 func (m *DGRound1Message) UnmarshalVCommitment() *big.Int {
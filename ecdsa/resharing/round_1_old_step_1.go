@@ -36,24 +36,28 @@ func (round *round1) Start() *tss.Error {
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	if !round.ReSharingParams().IsNewCommittee() {
+		round.allOldOK()
+	}
 
-	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.OldPartyIndex()
 
 	// 1. PrepareForSigning() -> w_i
 	xi, ks, bigXj := round.input.Xi, round.input.Ks, round.input.BigXj
 	if round.Threshold()+1 > len(ks) {
 		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
 	}
+	if round.input.ECDSAPub == nil || round.input.ECDSAPub.Curve() != round.EC() {
+		return round.WrapError(fmt.Errorf("the key is not on the %s curve set in the parameters", round.Curve()), round.PartyID())
+	}
 	newKs := round.NewParties().IDs().Keys()
-	wi, _, err := presign.PrepareForPresigning(i, len(round.OldParties().IDs()), xi, ks, bigXj)
+	wi, _, err := presign.PrepareForPresigning(round.EC(), i, len(round.OldParties().IDs()), xi, ks, bigXj)
 	if err != nil {
 		return round.WrapError(err, round.PartyID())
 	}
 
 	// 2.
-	vi, shares, err := vss.Create("ecdsa", round.NewThreshold(), wi, newKs)
+	vi, shares, err := vss.Create(round.Curve(), round.NewThreshold(), wi, newKs)
 	if err != nil {
 		return round.WrapError(err, round.PartyID())
 	}
@@ -72,7 +76,7 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.ECDSAPub, vCmt.C)
+		round.input.ECDSAPub, round.input.Epoch, vCmt.C)
 	round.temp.dgRound1Messages[i] = r1msg
 	round.out <- r1msg
 
@@ -102,9 +106,9 @@ func (round *round1) Update() (bool, *tss.Error) {
 		}
 		round.oldOK[j] = true
 
-		// save the ecdsa pub received from the old committee
-		r1msg := round.temp.dgRound1Messages[0].Content().(*DGRound1Message)
-		candidate, err := r1msg.UnmarshalECDSAPub()
+		// save the ecdsa pub and the next key epoch received from the old committee
+		r1msg := msg.Content().(*DGRound1Message)
+		candidate, err := r1msg.UnmarshalECDSAPub(round.Curve())
 		if err != nil {
 			return false, round.WrapError(errors.New("unable to unmarshal the ecdsa pub key"), msg.GetFrom())
 		}
@@ -113,7 +117,12 @@ func (round *round1) Update() (bool, *tss.Error) {
 			// uh oh - anomaly!
 			return false, round.WrapError(errors.New("ecdsa pub key did not match what we received previously"), msg.GetFrom())
 		}
+		if round.save.ECDSAPub != nil &&
+			r1msg.GetEpoch()+1 != round.save.Epoch {
+			return false, round.WrapError(errors.New("key epoch did not match what we received previously"), msg.GetFrom())
+		}
 		round.save.ECDSAPub = candidate
+		round.save.Epoch = r1msg.GetEpoch() + 1
 	}
 	return true, nil
 }
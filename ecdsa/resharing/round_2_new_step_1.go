@@ -28,7 +28,7 @@ func (round *round2) Start() *tss.Error {
 	}
 
 	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.NewPartyIndex()
 
 	// 2. "broadcast" "ACK" members of the OLD committee
 	r2msg1 := NewDGRound2Message2(
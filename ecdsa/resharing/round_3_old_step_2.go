@@ -24,16 +24,21 @@ func (round *round3) Start() *tss.Error {
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	if !round.ReSharingParams().IsNewCommittee() {
+		round.allOldOK()
+	}
 
-	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.OldPartyIndex()
 
 	// 2. send share to Pj from the new committee
 	for j, Pj := range round.NewParties().IDs() {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
-		round.temp.dgRound3Message1s[i] = r3msg1
+		if j == round.NewPartyIndex() {
+			// keep the share for our own place in the new committee
+			round.temp.dgRound3Message1s[i] = r3msg1
+			continue
+		}
 		round.out <- r3msg1
 	}
 
@@ -37,7 +37,7 @@ func (round *round4) Start() *tss.Error {
 	}
 
 	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.NewPartyIndex()
 
 	// 1-3. verify paillier & dln proofs, store message pieces, ensure uniqueness of h1j, h2j
 	h1H2Map := make(map[string]struct{}, len(round.temp.dgRound2Message1s)*2)
@@ -107,7 +107,7 @@ func (round *round4) Start() *tss.Error {
 	newXi := big.NewInt(0)
 
 	// 5-9.
-	modQ := common.ModInt(tss.EC("ecdsa").Params().N)
+	modQ := common.ModInt(round.EC().Params().N)
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		// 6-7.
@@ -123,7 +123,7 @@ func (round *round4) Start() *tss.Error {
 			// TODO collect culprits and return a list of them as per convention
 			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
 		}
-		vj, err := crypto.UnFlattenECPoints(tss.EC("ecdsa"), flatVs)
+		vj, err := crypto.UnFlattenECPoints(round.EC(), flatVs)
 		if err != nil {
 			return round.WrapError(err, round.Parties().IDs()[j])
 		}
@@ -136,13 +136,13 @@ func (round *round4) Start() *tss.Error {
 			ID:        round.PartyID().KeyInt(),
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
-		if ok := sharej.Verify("ecdsa", round.NewThreshold(), vj); !ok {
+		if ok := sharej.Verify(round.Curve(), round.NewThreshold(), vj); !ok {
 			// TODO collect culprits and return a list of them as per convention
 			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
 		}
 
 		// 9.
-		newXi = new(big.Int).Add(newXi, sharej.Share)
+		newXi = modQ.Add(newXi, sharej.Share)
 	}
 
 	// 10-13.
@@ -191,7 +191,7 @@ func (round *round4) Start() *tss.Error {
 	round.temp.newBigXjs = newBigXjs
 
 	// Send an "ACK" message to both committees to signal that we're ready to save our data
-	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
+	r4msg := NewDGRound4Message(tss.SortedPartyIDs(round.OldAndNewParties()).Exclude(Pi), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
 	round.out <- r4msg
 
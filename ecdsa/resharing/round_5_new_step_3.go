@@ -22,9 +22,11 @@ func (round *round5) Start() *tss.Error {
 	round.allOldOK()
 	round.allNewOK()
 
-	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.NewPartyIndex()
 
+	if round.IsOldCommittee() {
+		round.input.Xi.SetInt64(0)
+	}
 	if round.IsNewCommittee() {
 		// 21.
 		// for this P: SAVE data
@@ -41,8 +43,6 @@ func (round *round5) Start() *tss.Error {
 			r2msg1 := msg.Content().(*DGRound2Message1)
 			round.save.PaillierPKs[j] = r2msg1.UnmarshalPaillierPK()
 		}
-	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
 	}
 
 	round.end <- *round.save
@@ -0,0 +1,226 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/ecdsa/presign"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*BatchLocalParty)(nil)
+var _ fmt.Stringer = (*BatchLocalParty)(nil)
+
+type (
+	// BatchLocalParty signs a batch of messages, each with its own presignature, in the single round of online
+	// signing. Each party broadcasts the s_i of every message in one SignRound1Message.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		presignData []presign.LocalPresignData
+		temp        batchLocalTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *BatchSignatureData
+	}
+
+	batchLocalTempData struct {
+		signRound1Messages []tss.ParsedMessage
+
+		// temp data (thrown away after sign), indexed by message
+		ms,
+		sIs []*big.Int
+		keyEpoch uint64
+		// the digests given to NewBatchLocalPartyFromBytes, returned as the M of the signatures
+		digests [][]byte
+	}
+
+	// BatchSignatureData is the output of a BatchLocalParty. Signatures[k] is the signature of the message at k, or
+	// nil if that message failed the identifiable abort check, in which case Errors holds its MessageError. Every
+	// presignature of the batch is spent once the s_i are revealed, so the signatures that did verify are kept.
+	BatchSignatureData struct {
+		Signatures []*common.ECSignature
+		Errors     []*MessageError
+	}
+
+	// MessageError reports why the signature of the message at Index of a batch failed and which parties are to
+	// blame for it. The cause of a *tss.Error returned by a batch party is a *multierror.Error of MessageErrors when
+	// a failure is specific to some messages; use MessageErrors to retrieve them.
+	MessageError struct {
+		Index    int
+		Culprits []*tss.PartyID
+		Err      error
+	}
+)
+
+// NewBatchLocalParty returns a party that signs msgs[k] with presignData[k] for every k. The signatures are sent on
+// `end` together, in the order of `msgs`. Every presignature must be made in `keyEpoch` by the parties in `params`,
+// and no presignature may appear twice. If some messages fail, the party still sends the signatures of the others on
+// `end` and then returns a *tss.Error that blames the culprits of every failed message.
+func NewBatchLocalParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	keyEpoch uint64,
+	presignData []presign.LocalPresignData,
+	out chan<- tss.Message,
+	end chan<- *BatchSignatureData,
+) tss.Party {
+	if len(msgs) < 1 {
+		panic(errors.New("signing.NewBatchLocalParty expected at least 1 message"))
+	}
+	if len(msgs) != len(presignData) {
+		panic(fmt.Errorf("signing.NewBatchLocalParty expected a presign data per message, got %d for %d messages",
+			len(presignData), len(msgs)))
+	}
+	partyCount := len(params.Parties().IDs())
+	p := &BatchLocalParty{
+		BaseParty:   new(tss.BaseParty),
+		params:      params,
+		presignData: presignData,
+		temp:        batchLocalTempData{},
+		out:         out,
+		end:         end,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.ms = msgs
+	p.temp.sIs = make([]*big.Int, len(msgs))
+	p.temp.keyEpoch = keyEpoch
+	return p
+}
+
+// NewBatchLocalPartyFromBytes is NewBatchLocalParty for payloads that are hashed with `hash` as in
+// NewLocalPartyFromBytes. The digests are returned unchanged in the M of the signatures.
+func NewBatchLocalPartyFromBytes(
+	payloads [][]byte,
+	hash Hash,
+	params *tss.Parameters,
+	keyEpoch uint64,
+	presignData []presign.LocalPresignData,
+	out chan<- tss.Message,
+	end chan<- *BatchSignatureData,
+) tss.Party {
+	digests := make([][]byte, len(payloads))
+	msgs := make([]*big.Int, len(payloads))
+	for k, payload := range payloads {
+		digest, err := Digest(payload, hash)
+		if err != nil {
+			panic(fmt.Errorf("signing.NewBatchLocalPartyFromBytes: payload %d: %v", k, err))
+		}
+		digests[k], msgs[k] = digest, digestToInt(digest, params.EC())
+	}
+	p := NewBatchLocalParty(msgs, params, keyEpoch, presignData, out, end).(*BatchLocalParty)
+	p.temp.digests = digests
+	return p
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	return newBatchRound1(p.params, p.presignData, &p.temp, p.out, p.end)
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*batchRound1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// ----- //
+
+func (err *MessageError) Error() string {
+	return fmt.Sprintf("message %d, culprits %s: %v", err.Index, err.Culprits, err.Err)
+}
+
+func (err *MessageError) Unwrap() error { return err.Err }
+
+// MessageErrors returns the per-message failures carried by an error returned from a batch party, or nil if there
+// are none.
+func MessageErrors(err error) []*MessageError {
+	tssErr, ok := err.(*tss.Error)
+	if !ok || tssErr == nil {
+		return nil
+	}
+	multiErr, ok := tssErr.Cause().(*multierror.Error)
+	if !ok {
+		return nil
+	}
+	msgErrs := make([]*MessageError, 0, len(multiErr.Errors))
+	for _, e := range multiErr.Errors {
+		if msgErr, ok := e.(*MessageError); ok {
+			msgErrs = append(msgErrs, msgErr)
+		}
+	}
+	return msgErrs
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
+	"github.com/sisu-network/tss-lib/ecdsa/presign"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testBatchSize = 3
+)
+
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: presign a batch with t+1 of the parties
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, 2*len(signPIDs)*len(signPIDs))
+	presignEndCh := make(chan []*presign.LocalPresignData, len(signPIDs))
+	presignParties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		presignParties = append(presignParties, presign.NewBatchLocalParty(params, keys[i], testBatchSize, outCh, presignEndCh))
+	}
+	presigns := make([][]presign.LocalPresignData, len(signPIDs))
+	if !runParties(t, presignParties, outCh, errCh, func() {
+		for range signPIDs {
+			batch := <-presignEndCh
+			for i, pID := range signPIDs {
+				if pID.Id != batch[0].PartyId {
+					continue
+				}
+				for _, data := range batch {
+					presigns[i] = append(presigns[i], *data)
+				}
+			}
+		}
+	}) {
+		return
+	}
+
+	// PHASE: sign a message with each presignature in one round
+	msgs := make([]*big.Int, testBatchSize)
+	for k := range msgs {
+		msgs[k] = common.GetRandomPositiveInt(tss.EC("ecdsa").Params().N)
+	}
+	outCh = make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *BatchSignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewBatchLocalParty(msgs, params, keys[i].Epoch, presigns[i], outCh, endCh))
+	}
+	var sigs []*common.ECSignature
+	if !runParties(t, parties, outCh, errCh, func() {
+		for range signPIDs {
+			data := <-endCh
+			assert.Empty(t, data.Errors)
+			sigs = data.Signatures
+		}
+	}) {
+		return
+	}
+
+	assert.Len(t, sigs, testBatchSize)
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC("ecdsa"),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for k, sig := range sigs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, msgs[k].Bytes(), r, s), "ecdsa verify must pass for message %d", k)
+		assert.Equal(t, msgs[k].Bytes(), sig.M)
+	}
+
+	// PHASE: a party that sends a wrong s_i for one message is blamed for that message only, and the other messages
+	// are still signed
+	outCh = make(chan tss.Message, len(signPIDs))
+	endCh = make(chan *BatchSignatureData, len(signPIDs))
+	parties = parties[:0]
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewBatchLocalParty(msgs, params, keys[i].Epoch, presigns[i], outCh, endCh))
+		assert.Nil(t, parties[i].Start())
+	}
+	r1msgs := make([]tss.Message, len(signPIDs))
+	for range signPIDs {
+		msg := <-outCh
+		r1msgs[msg.GetFrom().Index] = msg
+	}
+	sIs := r1msgs[0].(tss.ParsedMessage).Content().(*SignRound1Message).UnmarshalBatchSi()
+	sIs[1] = new(big.Int).Add(sIs[1], big.NewInt(1))
+	r1msgs[0] = NewSignRound1BatchMessage(signPIDs[0], sIs)
+
+	for i, P := range parties {
+		// the party finalizes on the last message it receives
+		last := len(r1msgs) - 1
+		if i == last {
+			last--
+		}
+		for j, msg := range r1msgs {
+			if i == j {
+				continue
+			}
+			_, err := P.Update(msg.(tss.ParsedMessage))
+			if i == 0 || j != last {
+				assert.Nil(t, err)
+				continue
+			}
+			if assert.NotNil(t, err, "party %d must blame party 0", i) {
+				msgErrs := MessageErrors(err)
+				if assert.Len(t, msgErrs, 1) {
+					assert.Equal(t, 1, msgErrs[0].Index)
+					assert.Equal(t, []*tss.PartyID{signPIDs[0]}, msgErrs[0].Culprits)
+				}
+				assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+			}
+		}
+	}
+	failed := 0
+	for i := range parties {
+		data := <-endCh
+		if assert.Len(t, data.Signatures, testBatchSize) {
+			for k, sig := range data.Signatures {
+				if k == 1 && len(data.Errors) > 0 {
+					assert.Nil(t, sig, "the failed message must have no signature")
+					continue
+				}
+				if assert.NotNil(t, sig, "message %d must still be signed", k) {
+					r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+					assert.True(t, ecdsa.Verify(&pk, msgs[k].Bytes(), r, s), "ecdsa verify must pass for message %d", k)
+				}
+			}
+		}
+		// only party 0 received no tampered s_i
+		if len(data.Errors) == 0 {
+			continue
+		}
+		failed++
+		if assert.Len(t, data.Errors, 1, "result %d", i) {
+			assert.Equal(t, 1, data.Errors[0].Index)
+			assert.Equal(t, []*tss.PartyID{signPIDs[0]}, data.Errors[0].Culprits)
+		}
+	}
+	assert.Equal(t, len(parties)-1, failed, "every party but party 0 must report the failed message")
+
+	// PHASE: a presignature must not be used for two messages of a batch
+	dup := []presign.LocalPresignData{presigns[0][0], presigns[0][0]}
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	err = NewBatchLocalParty(msgs[:2], params, keys[0].Epoch, dup, outCh, endCh).Start()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "same presignature")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/ecdsa/presign"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+type (
+	// batchBase reuses the round bookkeeping of base; the single message fields of base are left unset
+	batchBase struct {
+		*base
+		presignData []presign.LocalPresignData
+		temp        *batchLocalTempData
+		end         chan<- *BatchSignatureData
+	}
+	batchRound1 struct {
+		*batchBase
+	}
+
+	batchFinalization struct {
+		*batchRound1
+	}
+)
+
+var (
+	_ tss.Round = (*batchRound1)(nil)
+	_ tss.Round = (*batchFinalization)(nil)
+)
+
+func newBatchRound1(params *tss.Parameters, presignData []presign.LocalPresignData, temp *batchLocalTempData, out chan<- tss.Message, end chan<- *BatchSignatureData) tss.Round {
+	return &batchRound1{
+		&batchBase{
+			&base{params, nil, nil, out, nil, make([]bool, len(params.Parties().IDs())), false, 1},
+			presignData, temp, end}}
+}
+
+func (round *batchRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	for k := range round.presignData {
+		round.temp.sIs[k] = calculateSi(&round.presignData[k], round.temp.ms[k])
+	}
+	round.out <- NewSignRound1BatchMessage(round.PartyID(), round.temp.sIs)
+	return nil
+}
+
+func (round *batchRound1) Update() (bool, *tss.Error) {
+	for j, msg1 := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *batchRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *batchRound1) NextRound() tss.Round {
+	round.started = false
+	return &batchFinalization{round}
+}
+
+// ----- //
+
+// prepare checks every message and presignature of the batch before any s_i is revealed
+func (round *batchRound1) prepare() error {
+	N := round.EC().Params().N
+	bigRs := make(map[string]int, len(round.presignData))
+	for k := range round.presignData {
+		data := &round.presignData[k]
+		if m := round.temp.ms[k]; m == nil || m.Cmp(N) >= 0 {
+			return fmt.Errorf("hashed message %d is not valid", k)
+		}
+		if data.ECDSAPub == nil || data.ECDSAPub.Curve() != round.EC() {
+			return fmt.Errorf("presign data %d is not on the %s curve set in the parameters", k, round.Curve())
+		}
+		if err := checkPresignData(data, round.temp.keyEpoch, round.Parties().IDs()); err != nil {
+			return fmt.Errorf("presign data %d: %v", k, err)
+		}
+		// signing two messages with one presignature reveals the key
+		id := string(data.BigR.GetX()) + "/" + string(data.BigR.GetY())
+		if first, used := bigRs[id]; used {
+			return fmt.Errorf("presign data %d and %d are the same presignature", first, k)
+		}
+		bigRs[id] = k
+	}
+	return nil
+}
+
+// ----- //
+
+func (round *batchFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 8
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	otherSIs := make([][]*big.Int, len(Ps))
+	for j, msg := range round.temp.signRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*SignRound1Message)
+		if !msg.ValidateBasic() || len(r1msg.GetBatchSi()) != len(round.presignData) {
+			culprits = append(culprits, Ps[j])
+			multiErr = multierror.Append(multiErr, fmt.Errorf("round 1: expected %d s_i values but got %d",
+				len(round.presignData), len(r1msg.GetBatchSi())))
+			continue
+		}
+		otherSIs[j] = r1msg.UnmarshalBatchSi()
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	// the type 8 identifiable abort check is run for each message on its own
+	signatures := make([]*common.ECSignature, len(round.presignData))
+	var msgErrs []*MessageError
+	for k := range round.presignData {
+		data := round.presignData[k]
+		pk := &ecdsa.PublicKey{
+			Curve: round.EC(),
+			X:     data.ECDSAPub.X(),
+			Y:     data.ECDSAPub.Y(),
+		}
+		sIs := make(map[*tss.PartyID]*big.Int, len(Ps)-1)
+		for j, Pj := range Ps {
+			if j == i {
+				continue
+			}
+			sIs[Pj] = otherSIs[j][k]
+		}
+		signature, _, err := FinalizeGetAndVerifyFinalSig(data, pk, round.temp.ms[k], Pi, round.temp.sIs[k], sIs)
+		if err != nil {
+			msgErrs = append(msgErrs, &MessageError{Index: k, Culprits: err.Culprits(), Err: err.Cause()})
+			continue
+		}
+		if round.temp.digests != nil {
+			signature.M = round.temp.digests[k]
+		}
+		signatures[k] = signature
+	}
+	// the signatures that verified are output even if others failed, as every presignature of the batch is spent
+	round.end <- &BatchSignatureData{Signatures: signatures, Errors: msgErrs}
+	if 0 < len(msgErrs) {
+		return round.wrapMessageErrors(msgErrs)
+	}
+	return nil
+}
+
+func (round *batchFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *batchFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *batchFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// wrapMessageErrors returns a *tss.Error whose cause lists every MessageError and whose culprits are all of the
+// parties blamed for any message.
+func (round *batchFinalization) wrapMessageErrors(msgErrs []*MessageError) *tss.Error {
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	blamed := make(map[int]struct{}, len(round.Parties().IDs()))
+	for _, msgErr := range msgErrs {
+		multiErr = multierror.Append(multiErr, msgErr)
+		for _, Pj := range msgErr.Culprits {
+			if _, found := blamed[Pj.Index]; found {
+				continue
+			}
+			blamed[Pj.Index] = struct{}{}
+			culprits = append(culprits, Pj)
+		}
+	}
+	return round.WrapError(multiErr, culprits...)
+}
@@ -0,0 +1,173 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+)
+
+// SigHashType is the byte appended to a DER signature in a Bitcoin script
+type SigHashType byte
+
+const (
+	SigHashAll          SigHashType = 0x01
+	SigHashNone         SigHashType = 0x02
+	SigHashSingle       SigHashType = 0x03
+	SigHashAnyOneCanPay SigHashType = 0x80
+)
+
+// Valid reports whether the type is ALL, NONE or SINGLE, optionally combined with ANYONECANPAY.
+func (hashType SigHashType) Valid() bool {
+	base := hashType &^ SigHashAnyOneCanPay
+	return SigHashAll <= base && base <= SigHashSingle
+}
+
+// DERSignature returns `sig` in strict DER (BIP66) with S in the lower half of the curve order (BIP62/BIP146).
+// The encoding is checked to parse back to the same R and S with btcec.ParseDERSignature.
+func DERSignature(sig *common.ECSignature) ([]byte, error) {
+	normalized, err := NormalizeS(sig)
+	if err != nil {
+		return nil, err
+	}
+	r, s := new(big.Int).SetBytes(normalized.R), new(big.Int).SetBytes(normalized.S)
+	rb, sb := derInt(r), derInt(s)
+	// 0x30 <length> 0x02 <length R> R 0x02 <length S> S
+	der := make([]byte, 0, 6+len(rb)+len(sb))
+	der = append(der, 0x30, byte(4+len(rb)+len(sb)))
+	der = append(der, 0x02, byte(len(rb)))
+	der = append(der, rb...)
+	der = append(der, 0x02, byte(len(sb)))
+	der = append(der, sb...)
+
+	parsed, err := ParseDERSignature(der)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.R.Cmp(r) != 0 || parsed.S.Cmp(s) != 0 {
+		return nil, errors.New("DERSignature() produced an encoding that does not round-trip")
+	}
+	return der, nil
+}
+
+// BitcoinSignature returns the DER signature followed by the sighash byte, as pushed in a Bitcoin script or witness.
+func BitcoinSignature(sig *common.ECSignature, hashType SigHashType) ([]byte, error) {
+	if !hashType.Valid() {
+		return nil, fmt.Errorf("invalid sighash type 0x%02x", byte(hashType))
+	}
+	der, err := DERSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	return append(der, byte(hashType)), nil
+}
+
+// ParseDERSignature parses a strict DER (BIP66) signature and refuses a high S (BIP62/BIP146).
+func ParseDERSignature(der []byte) (*btcec.Signature, error) {
+	if err := checkStrictDER(der); err != nil {
+		return nil, err
+	}
+	sig, err := btcec.ParseDERSignature(der, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	if sig.S.Cmp(new(big.Int).Rsh(btcec.S256().N, 1)) > 0 {
+		return nil, errors.New("the signature S value is not normalized")
+	}
+	return sig, nil
+}
+
+// ParseBitcoinSignature splits a signature made by BitcoinSignature into the parsed DER signature and its sighash type.
+func ParseBitcoinSignature(bz []byte) (*btcec.Signature, SigHashType, error) {
+	if len(bz) < 1 {
+		return nil, 0, errors.New("empty signature")
+	}
+	hashType := SigHashType(bz[len(bz)-1])
+	if !hashType.Valid() {
+		return nil, 0, fmt.Errorf("invalid sighash type 0x%02x", byte(hashType))
+	}
+	sig, err := ParseDERSignature(bz[:len(bz)-1])
+	if err != nil {
+		return nil, 0, err
+	}
+	return sig, hashType, nil
+}
+
+// VerifyDERSignature verifies the strict DER signature `der` of `digest` under the ECDSAPub point `pub`.
+func VerifyDERSignature(pub *crypto.ECPoint, digest, der []byte) error {
+	sig, err := ParseDERSignature(der)
+	if err != nil {
+		return err
+	}
+	return verifyBtcecSignature(pub, digest, sig)
+}
+
+// VerifyBitcoinSignature verifies a DER signature with a sighash suffix and returns the sighash type.
+// `digest` must be the sighash computed for that type.
+func VerifyBitcoinSignature(pub *crypto.ECPoint, digest, bz []byte) (SigHashType, error) {
+	sig, hashType, err := ParseBitcoinSignature(bz)
+	if err != nil {
+		return 0, err
+	}
+	if err = verifyBtcecSignature(pub, digest, sig); err != nil {
+		return 0, err
+	}
+	return hashType, nil
+}
+
+func verifyBtcecSignature(pub *crypto.ECPoint, digest []byte, sig *btcec.Signature) error {
+	if pub == nil || pub.Curve() != btcec.S256() {
+		return errors.New("expected a secp256k1 public key")
+	}
+	pk := &btcec.PublicKey{Curve: btcec.S256(), X: pub.X(), Y: pub.Y()}
+	if !sig.Verify(digest, pk) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// derInt returns the minimal big-endian encoding of a positive integer, with a 0x00 prefix when the high bit is set
+func derInt(i *big.Int) []byte {
+	bz := i.Bytes()
+	if len(bz) == 0 || bz[0]&0x80 != 0 {
+		bz = append([]byte{0x00}, bz...)
+	}
+	return bz
+}
+
+// checkStrictDER applies the rules of BIP66's IsValidSignatureEncoding to a signature without a sighash byte
+func checkStrictDER(der []byte) error {
+	if len(der) < 8 || len(der) > 72 {
+		return errors.New("DER signature has an invalid length")
+	}
+	if der[0] != 0x30 || int(der[1]) != len(der)-2 {
+		return errors.New("DER signature has an invalid sequence header")
+	}
+	lenR := int(der[3])
+	if der[2] != 0x02 || lenR == 0 || 5+lenR >= len(der) {
+		return errors.New("DER signature has an invalid R")
+	}
+	lenS := int(der[5+lenR])
+	if der[4+lenR] != 0x02 || lenS == 0 || lenR+lenS+6 != len(der) {
+		return errors.New("DER signature has an invalid S")
+	}
+	for _, v := range [][]byte{der[4 : 4+lenR], der[6+lenR:]} {
+		if v[0]&0x80 != 0 {
+			return errors.New("DER signature has a negative integer")
+		}
+		if 1 < len(v) && v[0] == 0x00 && v[1]&0x80 == 0 {
+			return errors.New("DER signature has an integer with excess padding")
+		}
+	}
+	return nil
+}
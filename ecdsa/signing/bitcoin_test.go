@@ -0,0 +1,74 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+)
+
+func TestBitcoinSignature(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(btcec.S256(), key.PubKey().X, key.PubKey().Y)
+	assert.NoError(t, err)
+	digest := sha256.Sum256([]byte("bitcoin"))
+	btcSig, err := key.Sign(digest[:])
+	assert.NoError(t, err)
+
+	// a signature with a high S must be normalized
+	highS := new(big.Int).Sub(btcec.S256().N, btcSig.S)
+	sig := &common.ECSignature{
+		R:                 btcSig.R.Bytes(),
+		S:                 highS.Bytes(),
+		Signature:         append(btcSig.R.Bytes(), highS.Bytes()...),
+		SignatureRecovery: []byte{0},
+		M:                 digest[:],
+	}
+
+	der, err := DERSignature(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, btcSig.Serialize(), der)
+	assert.NoError(t, VerifyDERSignature(pub, digest[:], der))
+
+	bz, err := BitcoinSignature(sig, SigHashAll|SigHashAnyOneCanPay)
+	assert.NoError(t, err)
+	assert.Equal(t, der, bz[:len(bz)-1])
+	hashType, err := VerifyBitcoinSignature(pub, digest[:], bz)
+	assert.NoError(t, err)
+	assert.Equal(t, SigHashAll|SigHashAnyOneCanPay, hashType)
+
+	_, err = BitcoinSignature(sig, 0x04)
+	assert.Error(t, err, "must reject an unknown sighash type")
+
+	other := sha256.Sum256([]byte("other"))
+	_, err = VerifyBitcoinSignature(pub, other[:], bz)
+	assert.Error(t, err, "must reject a signature of another digest")
+
+	// the high S encoding is not accepted
+	rb, sb := derInt(btcSig.R), derInt(highS)
+	highDER := append([]byte{0x30, byte(4 + len(rb) + len(sb)), 0x02, byte(len(rb))}, rb...)
+	highDER = append(append(highDER, 0x02, byte(len(sb))), sb...)
+	_, err = btcec.ParseDERSignature(highDER, btcec.S256())
+	assert.NoError(t, err, "the high S encoding is valid DER")
+	_, err = ParseDERSignature(highDER)
+	assert.Error(t, err)
+
+	// nor is an integer with excess padding
+	padded := append([]byte{0x30, byte(len(der) - 1), 0x02, der[3] + 1, 0x00}, der[4:]...)
+	_, err = btcec.ParseSignature(padded, btcec.S256())
+	assert.NoError(t, err, "the padded encoding is valid BER")
+	_, err = ParseDERSignature(padded)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash selects how a payload given to NewLocalPartyFromBytes is turned into the digest that is signed
+type Hash int
+
+const (
+	// Prehashed signs the payload as it is; it must already be a digest
+	Prehashed Hash = iota
+	// SHA256 signs SHA-256(payload)
+	SHA256
+	// DoubleSHA256 signs SHA-256(SHA-256(payload)), as Bitcoin does
+	DoubleSHA256
+	// Keccak256 signs the legacy Keccak-256 of the payload, as Ethereum does
+	Keccak256
+)
+
+// Digest returns the digest of `payload` under `hash`. A Prehashed payload is returned as a copy.
+func Digest(payload []byte, hash Hash) ([]byte, error) {
+	switch hash {
+	case Prehashed:
+		if len(payload) == 0 {
+			return nil, errors.New("a prehashed digest must not be empty")
+		}
+		return append([]byte(nil), payload...), nil
+	case SHA256:
+		sum := sha256.Sum256(payload)
+		return sum[:], nil
+	case DoubleSHA256:
+		sum := sha256.Sum256(payload)
+		sum = sha256.Sum256(sum[:])
+		return sum[:], nil
+	case Keccak256:
+		h := sha3.NewLegacyKeccak256()
+		_, _ = h.Write(payload)
+		return h.Sum(nil), nil
+	default:
+		return nil, fmt.Errorf("unknown hash %d", hash)
+	}
+}
+
+// digestToInt converts a digest to the integer that ECDSA signs on `curve`: the leftmost bits of the digest, as many
+// as the curve order has (FIPS 186-4, 6.4), reduced modulo the order. The signature verifies against the digest.
+func digestToInt(digest []byte, curve elliptic.Curve) *big.Int {
+	N := curve.Params().N
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e.Mod(e, N)
+}
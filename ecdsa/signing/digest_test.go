@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/ecdsa/presign"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func TestDigest(t *testing.T) {
+	vectors := []struct {
+		hash     Hash
+		payload  string
+		expected string
+	}{
+		{SHA256, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{DoubleSHA256, "abc", "4f8b42c22dd3729b519ba6f68d2da7cc5b2d606d05daed5ad5128cc03e6c6358"},
+		{Keccak256, "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{Prehashed, "\x00\x01", "0001"},
+	}
+	for _, v := range vectors {
+		digest, err := Digest([]byte(v.payload), v.hash)
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, hex.EncodeToString(digest), "hash %d", v.hash)
+	}
+	_, err := Digest(nil, Prehashed)
+	assert.Error(t, err, "an empty digest must be refused")
+	_, err = Digest([]byte("abc"), Hash(42))
+	assert.Error(t, err)
+}
+
+func TestDigestToInt(t *testing.T) {
+	ec := tss.EC("ecdsa")
+	N := ec.Params().N
+
+	// a digest longer than the order keeps its leftmost 256 bits
+	long := make([]byte, 64)
+	long[0], long[31], long[63] = 0x01, 0x02, 0x03
+	assert.Equal(t, 0, new(big.Int).SetBytes(long[:32]).Cmp(digestToInt(long, ec)))
+
+	// leading zero bytes do not change the value
+	assert.Equal(t, int64(0x0102), digestToInt([]byte{0x00, 0x00, 0x01, 0x02}, ec).Int64())
+
+	// a digest above the order is reduced
+	above := new(big.Int).Add(N, big.NewInt(7))
+	assert.Equal(t, int64(7), digestToInt(above.Bytes(), ec).Int64())
+}
+
+func TestE2EFromBytes(t *testing.T) {
+	setUp("info")
+
+	presigns, signPIDs, err := presign.LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.ECSignature, len(signPIDs))
+
+	// a prehashed digest with leading zero bytes must be returned byte for byte
+	digest := make([]byte, 32)
+	common.GetRandomPositiveInt(new(big.Int).Lsh(big.NewInt(1), 240)).FillBytes(digest[2:])
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalPartyFromBytes(digest, Prehashed, params, presigns[i], outCh, endCh))
+	}
+	var sig *common.ECSignature
+	if !runParties(t, parties, outCh, errCh, func() {
+		for range signPIDs {
+			sig = <-endCh
+		}
+	}) {
+		return
+	}
+
+	assert.Equal(t, digest, sig.M)
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC("ecdsa"),
+		X:     presigns[0].ECDSAPub.X(),
+		Y:     presigns[0].ECDSAPub.Y(),
+	}
+	r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+	assert.True(t, ecdsa.Verify(&pk, sig.M, r, s), "ecdsa verify of the digest must pass")
+	assert.NoError(t, sig.Verify(presigns[0].ECDSAPub))
+}
@@ -28,12 +28,14 @@ const (
 
 //
 // Represents a P2P message sent to each party during Phase 8 of the GG20 ECDSA TSS signing protocol.
+// A batch signing party sends the s_i of every message of the batch in batch_si and leaves si empty.
 type SignRound1Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Si []byte `protobuf:"bytes,1,opt,name=si,proto3" json:"si,omitempty"`
+	Si      []byte   `protobuf:"bytes,1,opt,name=si,proto3" json:"si,omitempty"`
+	BatchSi [][]byte `protobuf:"bytes,2,rep,name=batch_si,json=batchSi,proto3" json:"batch_si,omitempty"`
 }
 
 func (x *SignRound1Message) Reset() {
@@ -75,18 +77,27 @@ func (x *SignRound1Message) GetSi() []byte {
 	return nil
 }
 
+func (x *SignRound1Message) GetBatchSi() [][]byte {
+	if x != nil {
+		return x.BatchSi
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
 	0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x65, 0x63,
-	0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x23, 0x0a, 0x11, 0x53,
+	0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x3e, 0x0a, 0x11, 0x53,
 	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x12, 0x0e, 0x0a, 0x02, 0x73, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x69,
-	0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
-	0x69, 0x73, 0x75, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x74, 0x73, 0x73, 0x2d,
-	0x6c, 0x69, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e,
-	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x42, 0x2f, 0x5a, 0x2d, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x69, 0x73, 0x75, 0x2d, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x74, 0x73, 0x73, 0x2d, 0x6c, 0x69, 0x62, 0x2f, 0x65,
+	0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -0,0 +1,148 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/sisu-network/tss-lib/common"
+)
+
+// EthereumSignatureLength is the length of an r || s || v signature
+const EthereumSignatureLength = 65
+
+// EthereumTxType selects how EthereumV encodes the recovery id of a transaction signature
+type EthereumTxType int
+
+const (
+	// LegacyTx is a transaction without replay protection: v = 27 + recovery id
+	LegacyTx EthereumTxType = iota
+	// EIP155Tx is a legacy transaction with replay protection: v = chainID * 2 + 35 + recovery id
+	EIP155Tx
+	// TypedTx is an EIP-2718 typed transaction such as EIP-2930 or EIP-1559: v = recovery id (the y parity)
+	TypedTx
+)
+
+// NormalizeS returns a copy of `sig` with S in the lower half of the order of secp256k1 (EIP-2), flipping the recovery
+// id when S is negated. The signatures made by this package are normalized already.
+func NormalizeS(sig *common.ECSignature) (*common.ECSignature, error) {
+	if sig == nil || len(sig.R) == 0 || len(sig.S) == 0 || len(sig.SignatureRecovery) != 1 {
+		return nil, errors.New("NormalizeS() requires a signature with R, S and a one byte recovery id")
+	}
+	N := btcec.S256().N
+	r, s, recID := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S), sig.SignatureRecovery[0]
+	if r.Sign() == 0 || r.Cmp(N) >= 0 || s.Sign() == 0 || s.Cmp(N) >= 0 || recID > 3 {
+		return nil, errors.New("NormalizeS() received a signature that is out of range")
+	}
+	if s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+		s.Sub(N, s)
+		recID ^= 1
+	}
+	normalized := new(common.ECSignature)
+	normalized.R, normalized.S = r.Bytes(), s.Bytes()
+	normalized.Signature = append(r.Bytes(), s.Bytes()...)
+	normalized.SignatureRecovery = []byte{recID}
+	normalized.M = append([]byte(nil), sig.M...)
+	return normalized, nil
+}
+
+// EthereumSignature returns `sig` in the 65-byte r || s || v form of go-ethereum's crypto.Sign, where v is the
+// recovery id 0 or 1; add 27 for eth_sign and ecrecover. S is normalized, and the signature must recover `pk` for the
+// digest in sig.M.
+func EthereumSignature(sig *common.ECSignature, pk *ecdsa.PublicKey) ([]byte, error) {
+	if pk == nil || pk.Curve != btcec.S256() {
+		return nil, errors.New("EthereumSignature() requires a secp256k1 public key")
+	}
+	normalized, err := NormalizeS(sig)
+	if err != nil {
+		return nil, err
+	}
+	if normalized.SignatureRecovery[0] > 1 {
+		// R.X overflowed the curve order, which Ethereum cannot express
+		return nil, errors.New("EthereumSignature() cannot encode a recovery id greater than 1")
+	}
+	rsv := make([]byte, EthereumSignatureLength)
+	new(big.Int).SetBytes(normalized.R).FillBytes(rsv[:32])
+	new(big.Int).SetBytes(normalized.S).FillBytes(rsv[32:64])
+	rsv[64] = normalized.SignatureRecovery[0]
+
+	recovered, err := RecoverPublicKey(rsv, normalized.M)
+	if err != nil {
+		return nil, err
+	}
+	if recovered.X.Cmp(pk.X) != 0 || recovered.Y.Cmp(pk.Y) != 0 {
+		return nil, errors.New("EthereumSignature() recovered a public key that does not match")
+	}
+	return rsv, nil
+}
+
+// RecoverPublicKey returns the public key that made the 65-byte r || s || v signature `rsv` of `digest`.
+// v may be the recovery id 0 or 1, or 27 or 28 as used by eth_sign. High S values are refused.
+func RecoverPublicKey(rsv, digest []byte) (*ecdsa.PublicKey, error) {
+	if len(rsv) != EthereumSignatureLength {
+		return nil, fmt.Errorf("expected a signature of %d bytes, got %d", EthereumSignatureLength, len(rsv))
+	}
+	if len(digest) == 0 || 32 < len(digest) {
+		return nil, errors.New("expected a digest of 1 to 32 bytes")
+	}
+	v := rsv[64]
+	if 27 <= v {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("invalid signature v value %d", rsv[64])
+	}
+	N := btcec.S256().N
+	if s := new(big.Int).SetBytes(rsv[32:64]); s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+		return nil, errors.New("the signature S value is not normalized")
+	}
+	// btcec's compact format is <27 + recovery id> || r || s
+	compact := make([]byte, EthereumSignatureLength)
+	compact[0] = 27 + v
+	copy(compact[1:], rsv[:64])
+	hash := make([]byte, 32)
+	copy(hash[32-len(digest):], digest)
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, hash)
+	if err != nil {
+		return nil, err
+	}
+	return pub.ToECDSA(), nil
+}
+
+// EthereumV returns the v value to put into a transaction of `txType` signed with the 65-byte signature `rsv`.
+// `chainID` is required for EIP155Tx and ignored otherwise.
+func EthereumV(rsv []byte, txType EthereumTxType, chainID *big.Int) (*big.Int, error) {
+	if len(rsv) != EthereumSignatureLength {
+		return nil, fmt.Errorf("expected a signature of %d bytes, got %d", EthereumSignatureLength, len(rsv))
+	}
+	recID := rsv[64]
+	if 27 <= recID {
+		recID -= 27
+	}
+	if recID > 1 {
+		return nil, fmt.Errorf("invalid signature v value %d", rsv[64])
+	}
+	switch txType {
+	case LegacyTx:
+		return big.NewInt(27 + int64(recID)), nil
+	case EIP155Tx:
+		if chainID == nil || chainID.Sign() <= 0 {
+			return nil, errors.New("EIP-155 requires a positive chain id")
+		}
+		v := new(big.Int).Lsh(chainID, 1)
+		return v.Add(v, big.NewInt(35+int64(recID))), nil
+	case TypedTx:
+		return big.NewInt(int64(recID)), nil
+	default:
+		return nil, fmt.Errorf("unknown Ethereum transaction type %d", txType)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+)
+
+func TestEthereumSignature(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	digest := sha256.Sum256([]byte("ethereum"))
+	compact, err := btcec.SignCompact(btcec.S256(), key, digest[:], false)
+	assert.NoError(t, err)
+
+	N := btcec.S256().N
+	r, s, recID := new(big.Int).SetBytes(compact[1:33]), new(big.Int).SetBytes(compact[33:]), compact[0]-27
+
+	// a signature with a high S and the matching recovery id, as an unnormalized signer would produce
+	highS := new(big.Int).Sub(N, s)
+	sig := &common.ECSignature{
+		R:                 r.Bytes(),
+		S:                 highS.Bytes(),
+		Signature:         append(r.Bytes(), highS.Bytes()...),
+		SignatureRecovery: []byte{recID ^ 1},
+		M:                 digest[:],
+	}
+
+	normalized, err := NormalizeS(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Cmp(new(big.Int).SetBytes(normalized.S)))
+	assert.Equal(t, []byte{recID}, normalized.SignatureRecovery)
+
+	rsv, err := EthereumSignature(sig, key.PubKey().ToECDSA())
+	assert.NoError(t, err)
+	assert.Len(t, rsv, EthereumSignatureLength)
+	assert.Equal(t, compact[1:], rsv[:64])
+	assert.Equal(t, recID, rsv[64])
+
+	pub, err := RecoverPublicKey(rsv, digest[:])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, pub.X.Cmp(key.PubKey().X))
+	assert.Equal(t, 0, pub.Y.Cmp(key.PubKey().Y))
+
+	// the eth_sign form with v = 27 or 28 recovers the same key
+	ethSign := append([]byte(nil), rsv...)
+	ethSign[64] += 27
+	pub2, err := RecoverPublicKey(ethSign, digest[:])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, pub.X.Cmp(pub2.X))
+
+	// a different key must be refused
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	_, err = EthereumSignature(sig, other.PubKey().ToECDSA())
+	assert.Error(t, err)
+
+	// high S is refused on recovery
+	highRSV := append([]byte(nil), rsv...)
+	highS.FillBytes(highRSV[32:64])
+	highRSV[64] ^= 1
+	_, err = RecoverPublicKey(highRSV, digest[:])
+	assert.Error(t, err)
+}
+
+func TestEthereumV(t *testing.T) {
+	rsv := make([]byte, EthereumSignatureLength)
+	rsv[64] = 1
+
+	v, err := EthereumV(rsv, LegacyTx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(28), v.Int64())
+
+	v, err = EthereumV(rsv, EIP155Tx, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(38), v.Int64())
+
+	v, err = EthereumV(rsv, TypedTx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v.Int64())
+
+	_, err = EthereumV(rsv, EIP155Tx, nil)
+	assert.Error(t, err, "EIP-155 requires a chain id")
+
+	rsv[64] = 2
+	_, err = EthereumV(rsv, TypedTx, nil)
+	assert.Error(t, err)
+}
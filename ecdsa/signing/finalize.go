@@ -42,10 +42,10 @@ func FinalizeGetAndVerifyFinalSig(
 		return nil, nil, FinalizeWrapError(errors.New("len(otherSIs) != T"), ourP)
 	}
 
-	N := tss.EC("ecdsa").Params().N
+	N := pk.Curve.Params().N
 	modN := common.ModInt(N)
 
-	bigR, err := crypto.NewECPoint(tss.EC("ecdsa"),
+	bigR, err := crypto.NewECPoint(pk.Curve,
 		new(big.Int).SetBytes(presignData.BigR.GetX()),
 		new(big.Int).SetBytes(presignData.BigR.GetY()))
 	if err != nil {
@@ -64,14 +64,14 @@ func FinalizeGetAndVerifyFinalSig(
 		}
 
 		// prep for identify aborts in phase 7
-		bigRBarJ, err := crypto.NewECPoint(tss.EC("ecdsa"),
+		bigRBarJ, err := crypto.NewECPoint(pk.Curve,
 			new(big.Int).SetBytes(bigRBarJBz.GetX()),
 			new(big.Int).SetBytes(bigRBarJBz.GetY()))
 		if err != nil {
 			culprits = append(culprits, Pj)
 			continue
 		}
-		bigSI, err := crypto.NewECPoint(tss.EC("ecdsa"),
+		bigSI, err := crypto.NewECPoint(pk.Curve,
 			new(big.Int).SetBytes(bigSJBz.GetX()),
 			new(big.Int).SetBytes(bigSJBz.GetY()))
 		if err != nil {
@@ -165,7 +165,7 @@ func (round *finalization) Start() *tss.Error {
 
 		r1msg := msg.Content().(*SignRound1Message)
 
-		if !msg.ValidateBasic() {
+		if !msg.ValidateBasic() || len(r1msg.GetBatchSi()) != 0 {
 			culprits = append(culprits, Pj)
 			multiErr = multierror.Append(multiErr, fmt.Errorf("round 1: unexpected abort message while in success mode: %+v", r1msg))
 			continue
@@ -178,7 +178,7 @@ func (round *finalization) Start() *tss.Error {
 	}
 
 	pk := &ecdsa.PublicKey{
-		Curve: tss.EC("ecdsa"),
+		Curve: round.EC(),
 		X:     round.presignData.ECDSAPub.X(),
 		Y:     round.presignData.ECDSAPub.Y(),
 	}
@@ -186,6 +186,9 @@ func (round *finalization) Start() *tss.Error {
 	if err != nil {
 		return err
 	}
+	if round.temp.digest != nil {
+		signature.M = round.temp.digest
+	}
 
 	round.end <- signature
 	return nil
@@ -12,6 +12,7 @@ import (
 	"math/big"
 
 	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
 	"github.com/sisu-network/tss-lib/ecdsa/presign"
 	"github.com/sisu-network/tss-lib/tss"
 )
@@ -44,10 +45,14 @@ type (
 		// temp data (thrown away after sign) / round 1
 		m,
 		sI *big.Int
+		keyEpoch uint64
+		// the digest given to NewLocalPartyFromBytes, returned as the M of the signature
+		digest []byte
 	}
 )
 
 // Constructs a new ECDSA signing party. Note: msg may be left nil for one-round signing mode to only do the pre-processing steps.
+// Start fails if the presign data was made by another set of signers than the parties in `params`.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -68,6 +73,58 @@ func NewLocalParty(
 	p.temp.signRound1Message = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.m = msg
+	p.temp.keyEpoch = presignData.KeyEpoch
+	return p
+}
+
+// NewLocalPartyWithKey is NewLocalParty for the party holding the save data `key`. Start also fails if the presign
+// data was made in another epoch than the Epoch of `key`, i.e. before the key was refreshed or reshared.
+func NewLocalPartyWithKey(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	presignData presign.LocalPresignData,
+	out chan<- tss.Message,
+	end chan<- *common.ECSignature,
+) tss.Party {
+	p := NewLocalParty(msg, params, presignData, out, end).(*LocalParty)
+	p.temp.keyEpoch = key.Epoch
+	return p
+}
+
+// NewLocalPartyFromBytes constructs an ECDSA signing party for `payload`, which is hashed with `hash` or, when it is
+// Prehashed, signed as it is. The digest is truncated to the bit length of the curve order as FIPS 186-4 requires,
+// and returned unchanged in the M of the signature.
+func NewLocalPartyFromBytes(
+	payload []byte,
+	hash Hash,
+	params *tss.Parameters,
+	presignData presign.LocalPresignData,
+	out chan<- tss.Message,
+	end chan<- *common.ECSignature,
+) tss.Party {
+	digest, err := Digest(payload, hash)
+	if err != nil {
+		panic(fmt.Errorf("signing.NewLocalPartyFromBytes: %v", err))
+	}
+	p := NewLocalParty(digestToInt(digest, params.EC()), params, presignData, out, end).(*LocalParty)
+	p.temp.digest = digest
+	return p
+}
+
+// NewLocalPartyFromBytesWithKey is NewLocalPartyFromBytes for the party holding the save data `key`, with the epoch
+// check of NewLocalPartyWithKey.
+func NewLocalPartyFromBytesWithKey(
+	payload []byte,
+	hash Hash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	presignData presign.LocalPresignData,
+	out chan<- tss.Message,
+	end chan<- *common.ECSignature,
+) tss.Party {
+	p := NewLocalPartyFromBytes(payload, hash, params, presignData, out, end).(*LocalParty)
+	p.temp.keyEpoch = key.Epoch
 	return p
 }
 
@@ -8,6 +8,7 @@ package signing
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/sisu-network/tss-lib/common"
 	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/ecdsa/keygen"
 	"github.com/sisu-network/tss-lib/ecdsa/presign"
 	"github.com/sisu-network/tss-lib/test"
 	"github.com/sisu-network/tss-lib/tss"
@@ -140,3 +142,159 @@ signing:
 		}
 	}
 }
+
+func TestRejectStalePresignData(t *testing.T) {
+	presigns, signPIDs, err := presign.LoadPresignTestFixture(testThreshold + 1)
+	assert.NoError(t, err, "should load presign fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.ECSignature, len(signPIDs))
+	msg := big.NewInt(42)
+
+	// the key has been refreshed or reshared since the presignature was made
+	key := keygen.NewLocalPartySaveData(len(signPIDs))
+	key.Epoch = presigns[0].KeyEpoch + 1
+	err = NewLocalPartyWithKey(msg, params, key, presigns[0], outCh, endCh).Start()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "key epoch")
+	}
+
+	// the presignature was made by a different set of signers
+	unsorted := make(tss.UnSortedPartyIDs, testThreshold)
+	for j, pID := range signPIDs[:testThreshold] {
+		unsorted[j] = tss.NewPartyID(pID.Id, pID.Moniker, pID.KeyInt())
+	}
+	subset := tss.SortPartyIDs(unsorted)
+	params = tss.NewParameters(tss.NewPeerContext(subset), subset[0], len(subset), testThreshold-1)
+	err = NewLocalParty(msg, params, presigns[0], outCh, endCh).Start()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "signers")
+	}
+	assert.Empty(t, outCh, "no message may be sent for rejected presign data")
+}
+
+func TestE2EConcurrentP256(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: keygen on P-256, re-using the pre-params of the secp256k1 fixtures
+	fixtures, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	keygenEndCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+	keygenParties := make([]tss.Party, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		keygenParties = append(keygenParties, keygen.NewLocalParty(params, outCh, keygenEndCh, fixtures[i].LocalPreParams))
+	}
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	if !runParties(t, keygenParties, outCh, errCh, func() {
+		for range pIDs {
+			save := <-keygenEndCh
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			keys[index] = save
+		}
+	}) {
+		return
+	}
+	assert.Equal(t, elliptic.P256(), keys[0].ECDSAPub.Curve())
+	assert.NoError(t, keys[0].Validate())
+
+	// PHASE: presign with t+1 of the parties
+	signPIDs := pIDs[:threshold+1]
+	p2pCtx = tss.NewPeerContext(signPIDs)
+	outCh = make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	presignEndCh := make(chan *presign.LocalPresignData, len(signPIDs))
+	presignParties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		presignParties = append(presignParties, presign.NewLocalParty(params, keys[i], outCh, presignEndCh))
+	}
+	presigns := make([]presign.LocalPresignData, len(signPIDs))
+	if !runParties(t, presignParties, outCh, errCh, func() {
+		for range signPIDs {
+			data := <-presignEndCh
+			for i, pID := range signPIDs {
+				if pID.Id == data.PartyId {
+					presigns[i] = *data
+				}
+			}
+		}
+	}) {
+		return
+	}
+
+	// PHASE: signing
+	msg := common.GetRandomPositiveInt(elliptic.P256().Params().N)
+	outCh = make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	signEndCh := make(chan *common.ECSignature, len(signPIDs))
+	signParties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold).SetCurve(tss.EcdsaP256Scheme)
+		signParties = append(signParties, NewLocalPartyWithKey(msg, params, keys[i], presigns[i], outCh, signEndCh))
+	}
+	var sig *common.ECSignature
+	if !runParties(t, signParties, outCh, errCh, func() {
+		for range signPIDs {
+			sig = <-signEndCh
+		}
+	}) {
+		return
+	}
+
+	// the signature must verify with the standard library on P-256
+	pk := ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+	assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "P-256 ecdsa verify must pass")
+}
+
+// runParties starts the parties and routes their messages until `collect` returns.
+// Every party is started before any message is routed, so `outCh` must be able to hold all of the first round's
+// messages. It returns false if a party reported an error.
+func runParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, collect func()) bool {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+			return false
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		collect()
+		close(done)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return false
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-done:
+			return true
+		}
+	}
+}
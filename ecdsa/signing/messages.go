@@ -37,10 +37,32 @@ func NewSignRound1Message(from *tss.PartyID, sI *big.Int) tss.ParsedMessage {
 	return tss.NewMessage(meta, content, msg)
 }
 
+// NewSignRound1BatchMessage returns the round 1 message of a batch signing party, carrying the s_i of each message
+// in the order of the batch.
+func NewSignRound1BatchMessage(from *tss.PartyID, sIs []*big.Int) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          nil,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		BatchSi: common.BigIntsToBytes(sIs),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *SignRound1Message) ValidateBasic() bool {
-	if m == nil || m.Si == nil {
+	if m == nil {
 		return false
 	}
+	if len(m.BatchSi) == 0 {
+		return common.NonEmptyBytes(m.Si)
+	}
+	return len(m.Si) == 0 && common.NonEmptyMultiBytes(m.BatchSi)
+}
 
-	return common.NonEmptyBytes(m.Si)
+// UnmarshalBatchSi returns the s_i of each message of a batch
+func (m *SignRound1Message) UnmarshalBatchSi() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetBatchSi())
 }
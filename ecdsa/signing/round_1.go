@@ -8,6 +8,7 @@ package signing
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	common "github.com/sisu-network/tss-lib/common"
@@ -26,7 +27,7 @@ func newRound1(params *tss.Parameters, presignData *presign.LocalPresignData, te
 }
 
 func calculateSi(data *presign.LocalPresignData, msg *big.Int) (sI *big.Int) {
-	N := tss.EC("ecdsa").Params().N
+	N := data.ECDSAPub.Curve().Params().N
 	modN := common.ModInt(N)
 
 	kI, rSigmaI := new(big.Int).SetBytes(data.KI), new(big.Int).SetBytes(data.RSigmaI)
@@ -44,9 +45,12 @@ func (round *round1) Start() *tss.Error {
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
 	if round.temp.m != nil &&
-		round.temp.m.Cmp(tss.EC("ecdsa").Params().N) >= 0 {
+		round.temp.m.Cmp(round.EC().Params().N) >= 0 {
 		return round.WrapError(errors.New("hashed message is not valid"))
 	}
+	if round.presignData.ECDSAPub == nil || round.presignData.ECDSAPub.Curve() != round.EC() {
+		return round.WrapError(fmt.Errorf("the presign data is not on the %s curve set in the parameters", round.Curve()))
+	}
 
 	round.number = 1
 	round.started = true
@@ -93,5 +97,22 @@ func (round *round1) NextRound() tss.Round {
 // ----- //
 
 func (round *round1) prepare() error {
+	return checkPresignData(round.presignData, round.temp.keyEpoch, round.Parties().IDs())
+}
+
+// checkPresignData returns an error if `data` was not made in `keyEpoch` by exactly the `signers`
+func checkPresignData(data *presign.LocalPresignData, keyEpoch uint64, signers tss.SortedPartyIDs) error {
+	if data.KeyEpoch != keyEpoch {
+		return fmt.Errorf("the presign data was made in key epoch %d but the key is in epoch %d; "+
+			"presignatures cannot be used after the key is refreshed or reshared", data.KeyEpoch, keyEpoch)
+	}
+	if len(data.BigSJ) != len(signers) {
+		return fmt.Errorf("the presign data was made by %d signers but %d are signing", len(data.BigSJ), len(signers))
+	}
+	for _, Pj := range signers {
+		if _, ok := data.BigSJ[Pj.Id]; !ok {
+			return fmt.Errorf("the presign data was made by a different set of signers; %s did not take part", Pj)
+		}
+	}
 	return nil
 }
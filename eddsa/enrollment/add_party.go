@@ -0,0 +1,74 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+)
+
+// AddEnrolledParty extends the save data `key` of a party that did not help with an enrollment with the new party,
+// given the PublicData() of the new party's save data. The public share of the new party must lie on the key
+// polynomial and the new party must agree with `key` on every other party, so a tampered public data is rejected.
+func AddEnrolledParty(key keygen.LocalPartySaveData, enrolled keygen.LocalPartyPublicData) (keygen.LocalPartySaveData, error) {
+	if err := enrolled.Validate(); err != nil {
+		return key, fmt.Errorf("AddEnrolledParty: %v", err)
+	}
+	if len(enrolled.Ks) != len(key.Ks)+1 {
+		return key, fmt.Errorf("AddEnrolledParty: expected %d parties in the public data but got %d",
+			len(key.Ks)+1, len(enrolled.Ks))
+	}
+	if !enrolled.EDDSAPub.Equals(key.EDDSAPub) || !bytes.Equal(enrolled.ChainCode, key.ChainCode) {
+		return key, errors.New("AddEnrolledParty: the public data belongs to a different key")
+	}
+	index := make(map[string]int, len(enrolled.Ks))
+	for j, kj := range enrolled.Ks {
+		index[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newIdx, ok := index[hex.EncodeToString(enrolled.ShareID.Bytes())]
+	if !ok {
+		return key, errors.New("AddEnrolledParty: ShareID was not found in Ks")
+	}
+	for j, kj := range key.Ks {
+		e, ok := index[hex.EncodeToString(kj.Bytes())]
+		if !ok || e == newIdx {
+			return key, fmt.Errorf("AddEnrolledParty: Ks[%d] is missing from the public data", j)
+		}
+		if !enrolled.BigXj[e].Equals(key.BigXj[j]) {
+			return key, fmt.Errorf("AddEnrolledParty: BigXj differs for Ks[%d]", j)
+		}
+	}
+	before, err := key.PublicData().Threshold()
+	if err != nil {
+		return key, fmt.Errorf("AddEnrolledParty: %v", err)
+	}
+	if after, _ := enrolled.Threshold(); after != before {
+		return key, fmt.Errorf("AddEnrolledParty: the threshold of the public data is %d, not %d", after, before)
+	}
+	return withParty(key, enrolled.ShareID, enrolled.BigXj[newIdx]), nil
+}
+
+// ----- //
+
+// withParty returns a copy of `key` with the party of share ID `k` and public share `bigX` appended
+func withParty(key keygen.LocalPartySaveData, k *big.Int, bigX *crypto.ECPoint) keygen.LocalPartySaveData {
+	n := len(key.Ks)
+	save := keygen.NewLocalPartySaveData(n + 1)
+	save.LocalSecrets = key.LocalSecrets
+	save.EDDSAPub = key.EDDSAPub
+	save.ChainCode = key.ChainCode
+	copy(save.Ks, key.Ks)
+	copy(save.BigXj, key.BigXj)
+	save.Ks[n], save.BigXj[n] = k, bigX
+	return save
+}
@@ -0,0 +1,168 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package enrollment adds one party to an EdDSA key without resharing it: t+1 parties of the key, the helpers, deal
+// the new party the share of the key polynomial at its own index, and no other share changes. Each helper sends its
+// Lagrange-weighted share lambda_i*xi to the new party, blinded by pairwise masks that cancel out in the sum, so the
+// new party learns nothing but its own share. It checks the share against the public shares of the helpers and
+// acknowledges it, after which the helpers output their save data extended with the new party. The parties of the
+// key that did not help add the new party to their save data with AddEnrolledParty.
+//
+// To remove a party, the remaining parties run eddsa/refresh with refresh.NewRemovalParty instead.
+package enrollment
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		enRound1Messages,
+		enRound2Messages,
+		enRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after enrollment)
+		enrollee bool            // whether this party is the new party
+		newIdx   int             // the index of the new party in the parameters
+		newBigX  *crypto.ECPoint // the public share of the new party, interpolated from those of the helpers
+		masks    []*big.Int      // the masks that this helper sent to the other helpers
+	}
+)
+
+// Exported, used in `tss` client
+// NewLocalParty returns the party of a helper. `params` holds the t+1 helpers and the new party, with the threshold of
+// the key, and `key` is the save data of the helper.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.enRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.enRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.enRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.masks = make([]*big.Int, partyCount)
+	return p
+}
+
+// NewEnrolleeParty returns the party of the new party. `params` is as for NewLocalParty and `key` is the public data
+// of the key, e.g. the PublicData() of one of the helpers, which the new party should check with the other parties.
+func NewEnrolleeParty(
+	params *tss.Parameters,
+	key keygen.LocalPartyPublicData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	input := keygen.NewLocalPartySaveData(len(key.Ks))
+	copy(input.Ks, key.Ks)
+	copy(input.BigXj, key.BigXj)
+	input.EDDSAPub = key.EDDSAPub
+	input.ChainCode = key.ChainCode
+	p := NewLocalParty(params, input, out, end).(*LocalParty)
+	p.temp.enrollee = true
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *EnrollmentRound1Message:
+		p.temp.enRound1Messages[fromPIdx] = msg
+	case *EnrollmentRound2Message:
+		p.temp.enRound2Messages[fromPIdx] = msg
+	case *EnrollmentRound3Message:
+		p.temp.enRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
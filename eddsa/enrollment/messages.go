@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-enrollment.pb.go
+
+var (
+	// Ensure that enrollment messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*EnrollmentRound1Message)(nil),
+		(*EnrollmentRound2Message)(nil),
+		(*EnrollmentRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewEnrollmentRound1Message(
+	to, from *tss.PartyID,
+	mask *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &EnrollmentRound1Message{
+		Mask: mask.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetMask())
+}
+
+func (m *EnrollmentRound1Message) UnmarshalMask() *big.Int {
+	return new(big.Int).SetBytes(m.GetMask())
+}
+
+// ----- //
+
+func NewEnrollmentRound2Message(
+	to, from *tss.PartyID,
+	share *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &EnrollmentRound2Message{
+		Share: share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *EnrollmentRound2Message) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetShare())
+}
+
+// ----- //
+
+func NewEnrollmentRound3Message(
+	from *tss.PartyID,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &EnrollmentRound3Message{}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EnrollmentRound3Message) ValidateBasic() bool {
+	return m != nil
+}
@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package enrollment
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+	newIdx := round.temp.newIdx
+
+	// the new party waits for the share of every helper
+	if round.temp.enrollee {
+		round.ok[i] = true
+		return nil
+	}
+	round.allOK()
+
+	// 1. lambda_i = prod (k_new - kj) / (ki - kj) over the other helpers, so that sum lambda_j*xj = f(k_new)
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	ki, kNew := Pi.KeyInt(), Ps[newIdx].KeyInt()
+	lambda := big.NewInt(1)
+	for _, Pj := range round.helpers() {
+		kj := Pj.KeyInt()
+		if kj.Cmp(ki) == 0 {
+			continue
+		}
+		// big.Int Div is calculated as: a/b = a * modInv(b,q)
+		lambda = modQ.Mul(lambda, modQ.Mul(modQ.Sub(kNew, kj), modQ.Inverse(modQ.Sub(ki, kj))))
+	}
+
+	// 2. blind lambda_i*xi with the masks sent to and received from the other helpers
+	share := modQ.Mul(lambda, round.input.Xi)
+	for j, msg := range round.temp.enRound1Messages {
+		if j == i || j == newIdx {
+			continue
+		}
+		share = modQ.Add(share, round.temp.masks[j])
+		share = modQ.Sub(share, msg.Content().(*EnrollmentRound1Message).UnmarshalMask())
+	}
+
+	// 3. p2p send the blinded share to the new party
+	round.out <- NewEnrollmentRound2Message(Ps[newIdx], Pi, share)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*EnrollmentRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.enRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,245 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/eddsa-frost.proto
+
+package frost
+
+import (
+	common "github.com/sisu-network/tss-lib/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//
+// Represents a BROADCAST message sent to all parties during the preprocessing round of FROST.
+// It holds the nonce commitments D_k and E_k of each position k of the batch.
+type PreprocessRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hidings  []*common.ECPoint `protobuf:"bytes,1,rep,name=hidings,proto3" json:"hidings,omitempty"`
+	Bindings []*common.ECPoint `protobuf:"bytes,2,rep,name=bindings,proto3" json:"bindings,omitempty"`
+}
+
+func (x *PreprocessRound1Message) Reset() {
+	*x = PreprocessRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_frost_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreprocessRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreprocessRound1Message) ProtoMessage() {}
+
+func (x *PreprocessRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_frost_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreprocessRound1Message.ProtoReflect.Descriptor instead.
+func (*PreprocessRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_frost_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreprocessRound1Message) GetHidings() []*common.ECPoint {
+	if x != nil {
+		return x.Hidings
+	}
+	return nil
+}
+
+func (x *PreprocessRound1Message) GetBindings() []*common.ECPoint {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during the single online round of FROST signing.
+type SignRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position uint64 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	Z        []byte `protobuf:"bytes,2,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *SignRound1Message) Reset() {
+	*x = SignRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_frost_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRound1Message) ProtoMessage() {}
+
+func (x *SignRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_frost_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRound1Message.ProtoReflect.Descriptor instead.
+func (*SignRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_frost_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignRound1Message) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *SignRound1Message) GetZ() []byte {
+	if x != nil {
+		return x.Z
+	}
+	return nil
+}
+
+var File_protob_eddsa_frost_proto protoreflect.FileDescriptor
+
+var file_protob_eddsa_frost_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x65, 0x64, 0x64, 0x73,
+	0x61, 0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x1a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x17,
+	0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x07, 0x68, 0x69, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x07, 0x68, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x62,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x22, 0x3d, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x7a,
+	0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
+	0x69, 0x73, 0x75, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x74, 0x73, 0x73, 0x2d,
+	0x6c, 0x69, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_eddsa_frost_proto_rawDescOnce sync.Once
+	file_protob_eddsa_frost_proto_rawDescData = file_protob_eddsa_frost_proto_rawDesc
+)
+
+func file_protob_eddsa_frost_proto_rawDescGZIP() []byte {
+	file_protob_eddsa_frost_proto_rawDescOnce.Do(func() {
+		file_protob_eddsa_frost_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_eddsa_frost_proto_rawDescData)
+	})
+	return file_protob_eddsa_frost_proto_rawDescData
+}
+
+var file_protob_eddsa_frost_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protob_eddsa_frost_proto_goTypes = []interface{}{
+	(*PreprocessRound1Message)(nil), // 0: eddsa.frost.PreprocessRound1Message
+	(*SignRound1Message)(nil),       // 1: eddsa.frost.SignRound1Message
+	(*common.ECPoint)(nil),          // 2: ECPoint
+}
+var file_protob_eddsa_frost_proto_depIdxs = []int32{
+	2, // 0: eddsa.frost.PreprocessRound1Message.hidings:type_name -> ECPoint
+	2, // 1: eddsa.frost.PreprocessRound1Message.bindings:type_name -> ECPoint
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_protob_eddsa_frost_proto_init() }
+func file_protob_eddsa_frost_proto_init() {
+	if File_protob_eddsa_frost_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_eddsa_frost_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreprocessRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_frost_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_eddsa_frost_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_eddsa_frost_proto_goTypes,
+		DependencyIndexes: file_protob_eddsa_frost_proto_depIdxs,
+		MessageInfos:      file_protob_eddsa_frost_proto_msgTypes,
+	}.Build()
+	File_protob_eddsa_frost_proto = out.File
+	file_protob_eddsa_frost_proto_rawDesc = nil
+	file_protob_eddsa_frost_proto_goTypes = nil
+	file_protob_eddsa_frost_proto_depIdxs = nil
+}
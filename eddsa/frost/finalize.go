@@ -0,0 +1,81 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	// identify the parties that signed at another position or whose z_j does not verify
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		if r1msg.GetPosition() != uint64(round.temp.position) || !round.verifyZ(j, r1msg.UnmarshalZ()) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("z_j verification failed"), culprits...)
+	}
+
+	// z = sum_j z_j
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	z := round.temp.zi
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		z = modQ.Add(z, r1msg.UnmarshalZ())
+	}
+
+	// save the signature for final output; it is R || z with both little endian as in RFC 8032
+	encR := serializeElement(round.temp.bigR)
+	encZ := serializeScalar(z)
+	signature := new(common.ECSignature)
+	signature.Signature = append(encR, encZ...)
+	signature.R = common.LittleEndianToInt(encR).Bytes()
+	signature.S = z.Bytes()
+	signature.M = round.temp.m
+	round.data.Signature = signature
+
+	if err := round.data.Verify(round.key.EDDSAPub); err != nil {
+		return round.WrapError(fmt.Errorf("signature verification failed: %v", err))
+	}
+	round.end <- round.data
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,158 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty signs a message with FROST in a single round, using the nonces at one position of a batch made by
+	// the PreprocessParty. The signature is a standard Ed25519 signature under EDDSAPub.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys       keygen.LocalPartySaveData
+		preprocess *PreprocessData
+		temp       localTempData
+		data       signing.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *signing.SignatureData
+	}
+
+	localTempData struct {
+		signRound1Messages []tss.ParsedMessage
+
+		// temp data (thrown away after sign)
+		m        []byte // the message as it is signed, without hashing
+		position int
+		wi,
+		c *big.Int
+		rhos []*big.Int
+		// the nonce commitments Dj, Ej at the position, lambda_j*Xj of each signer, and the group commitment R
+		bigDs,
+		bigEs,
+		bigWs []*crypto.ECPoint
+		bigR *crypto.ECPoint
+		zi   *big.Int
+	}
+)
+
+// NewLocalParty returns a party that signs the raw message bytes `msg` with the nonces at `position` of
+// `preprocess`. The batch must have been made by exactly the parties in `params`. The nonces are erased from
+// `preprocess` as soon as the party starts; save it again afterwards so that they are never used twice.
+func NewLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	preprocess *PreprocessData,
+	position int,
+	out chan<- tss.Message,
+	end chan<- *signing.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty:  new(tss.BaseParty),
+		params:     params,
+		keys:       keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		preprocess: preprocess,
+		temp:       localTempData{},
+		data:       signing.SignatureData{},
+		out:        out,
+		end:        end,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.m = append([]byte(nil), msg...)
+	p.temp.position = position
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return &round1{&base{newRounds(p.params, TaskName), &p.keys, p.preprocess, &p.data, &p.temp, p.out, p.end}}
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
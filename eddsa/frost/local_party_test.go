@@ -0,0 +1,193 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+	testBatchSize    = 2
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// PHASE: preprocess a batch of nonces
+	preprocess := runPreprocess(t, p2pCtx, signPIDs, testBatchSize)
+	if preprocess == nil {
+		return
+	}
+	for i := range signPIDs {
+		assert.Equal(t, testBatchSize, preprocess[i].BatchSize())
+	}
+
+	// PHASE: sign a message at each position in one round
+	pub := ed25519.PublicKey(serializeElement(keys[0].EDDSAPub))
+	for position, msg := range [][]byte{[]byte("hello frost"), {0x00, 0x01, 0x02}} {
+		sig := runSigning(t, p2pCtx, signPIDs, keys, preprocess, position, msg)
+		if sig == nil {
+			return
+		}
+		assert.True(t, ed25519.Verify(pub, msg, sig.Signature.Signature), "ed25519 verify must pass")
+		assert.NoError(t, sig.VerifyBytes(keys[0].EDDSAPub, msg))
+		assert.Equal(t, msg, sig.Signature.M)
+		for i := range signPIDs {
+			assert.True(t, preprocess[i].Used(position), "the nonces must be erased once used")
+		}
+	}
+
+	// PHASE: nonces must not be used twice
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *signing.SignatureData, len(signPIDs))
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	err = NewLocalParty([]byte("again"), params, keys[0], preprocess[0], 0, outCh, endCh).Start()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "already been used")
+	}
+}
+
+func TestIdentifiableAbort(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	preprocess := runPreprocess(t, p2pCtx, signPIDs, 1)
+	if preprocess == nil {
+		return
+	}
+
+	// PHASE: party 0 sends a wrong z_i and is blamed by everyone else
+	msg := []byte("blame")
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *signing.SignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalParty(msg, params, keys[i], preprocess[i], 0, outCh, endCh))
+		assert.Nil(t, parties[i].Start())
+	}
+	r1msgs := make([]tss.Message, len(signPIDs))
+	for range signPIDs {
+		msg := <-outCh
+		r1msgs[msg.GetFrom().Index] = msg
+	}
+	zi := r1msgs[0].(tss.ParsedMessage).Content().(*SignRound1Message).UnmarshalZ()
+	r1msgs[0] = NewSignRound1Message(signPIDs[0], 0, new(big.Int).Add(zi, big.NewInt(1)))
+
+	for i, P := range parties[1:] {
+		var err *tss.Error
+		for j, msg := range r1msgs {
+			if i+1 == j {
+				continue
+			}
+			_, err = P.Update(msg.(tss.ParsedMessage))
+		}
+		if assert.NotNil(t, err, "party %d must blame party 0", i+1) {
+			assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+		}
+	}
+}
+
+func runPreprocess(t *testing.T, p2pCtx *tss.PeerContext, pIDs tss.SortedPartyIDs, batchSize int) []*PreprocessData {
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endChs := make([]chan *PreprocessData, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		endChs[i] = make(chan *PreprocessData, 1)
+		parties = append(parties, NewPreprocessParty(params, batchSize, outCh, endChs[i]))
+	}
+	preprocess := make([]*PreprocessData, len(pIDs))
+	if !runParties(t, parties, outCh, errCh, func() {
+		for i := range pIDs {
+			preprocess[i] = <-endChs[i]
+		}
+	}) {
+		return nil
+	}
+	return preprocess
+}
+
+func runSigning(t *testing.T, p2pCtx *tss.PeerContext, pIDs tss.SortedPartyIDs, keys []keygen.LocalPartySaveData,
+	preprocess []*PreprocessData, position int, msg []byte) *signing.SignatureData {
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan *signing.SignatureData, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		parties = append(parties, NewLocalParty(msg, params, keys[i], preprocess[i], position, outCh, endCh))
+	}
+	var sig *signing.SignatureData
+	if !runParties(t, parties, outCh, errCh, func() {
+		for range pIDs {
+			sig = <-endCh
+		}
+	}) {
+		return nil
+	}
+	return sig
+}
+
+func runParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, collect func()) bool {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+			return false
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		collect()
+		close(done)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return false
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-done:
+			return true
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-frost.pb.go
+
+var (
+	// Ensure that FROST messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PreprocessRound1Message)(nil),
+		(*SignRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPreprocessRound1Message(
+	from *tss.PartyID,
+	bigDs, bigEs []*crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreprocessRound1Message{
+		Hidings:  make([]*common.ECPoint, len(bigDs)),
+		Bindings: make([]*common.ECPoint, len(bigEs)),
+	}
+	for k := range bigDs {
+		content.Hidings[k] = bigDs[k].ToProtobufPoint()
+		content.Bindings[k] = bigEs[k].ToProtobufPoint()
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreprocessRound1Message) ValidateBasic() bool {
+	if m == nil || len(m.GetHidings()) == 0 || len(m.GetHidings()) != len(m.GetBindings()) {
+		return false
+	}
+	for k := range m.GetHidings() {
+		if !m.Hidings[k].ValidateBasic() || !m.Bindings[k].ValidateBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalCommitments returns the nonce commitments D_k and E_k, each of which must be a valid commitment
+func (m *PreprocessRound1Message) UnmarshalCommitments() (bigDs, bigEs []*crypto.ECPoint, err error) {
+	bigDs = make([]*crypto.ECPoint, len(m.GetHidings()))
+	bigEs = make([]*crypto.ECPoint, len(m.GetBindings()))
+	for k := range bigDs {
+		if bigDs[k], err = crypto.NewECPointFromProtobuf("eddsa", m.GetHidings()[k]); err != nil {
+			return nil, nil, err
+		}
+		if bigEs[k], err = crypto.NewECPointFromProtobuf("eddsa", m.GetBindings()[k]); err != nil {
+			return nil, nil, err
+		}
+		if !validCommitment(bigDs[k]) || !validCommitment(bigEs[k]) {
+			return nil, nil, errors.New("a nonce commitment is the identity or is not in the prime order subgroup")
+		}
+	}
+	return
+}
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	position int,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		Position: uint64(position),
+		Z:        zi.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignRound1Message) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// PreprocessData is a batch of nonces made by the preprocessing party. Each position of the batch signs one message.
+// Store it locally as it is; it must be saved again after every signature, as the nonces of the used position are
+// erased so that they are never used twice.
+type PreprocessData struct {
+	// secret fields (not shared, but stored locally); erased once used
+	Hidings, Bindings []*big.Int // d_k, e_k
+
+	// the keys of the parties that made the batch, in the order of tss.SortedPartyIDs
+	Ks []*big.Int
+
+	// the public nonce commitments of each party Pj at each position k
+	BigDs, BigEs [][]*crypto.ECPoint // D_jk, E_jk
+}
+
+// BatchSize returns the number of positions of the batch
+func (data *PreprocessData) BatchSize() int {
+	return len(data.Hidings)
+}
+
+// Used returns true when the nonces of `position` have been used or erased
+func (data *PreprocessData) Used(position int) bool {
+	return position < 0 || data.BatchSize() <= position || data.Hidings[position] == nil || data.Bindings[position] == nil
+}
+
+// erase removes the secret nonces of `position`
+func (data *PreprocessData) erase(position int) {
+	data.Hidings[position], data.Bindings[position] = nil, nil
+}
+
+// check ensures that the batch was made by `signers`, with Pi being the local party, and that `position` is unused
+func (data *PreprocessData) check(signers tss.SortedPartyIDs, i, position int) error {
+	if data == nil {
+		return errors.New("the preprocess data is nil")
+	}
+	if data.Used(position) {
+		return fmt.Errorf("the nonces at position %d have already been used", position)
+	}
+	if len(data.Ks) != len(signers) || len(data.BigDs) != len(signers) || len(data.BigEs) != len(signers) {
+		return fmt.Errorf("the preprocess data was made by %d parties but there are %d signers", len(data.Ks), len(signers))
+	}
+	for j, Pj := range signers {
+		if data.Ks[j] == nil || data.Ks[j].Cmp(Pj.KeyInt()) != 0 {
+			return fmt.Errorf("the preprocess data was not made by signer %s", Pj)
+		}
+		if len(data.BigDs[j]) != data.BatchSize() || len(data.BigEs[j]) != data.BatchSize() {
+			return fmt.Errorf("the preprocess data of signer %s has the wrong batch size", Pj)
+		}
+		if !validCommitment(data.BigDs[j][position]) || !validCommitment(data.BigEs[j][position]) {
+			return fmt.Errorf("the nonce commitments of signer %s are not valid", Pj)
+		}
+	}
+	ec := tss.EC("eddsa")
+	if !crypto.ScalarBaseMult(ec, data.Hidings[position]).Equals(data.BigDs[i][position]) ||
+		!crypto.ScalarBaseMult(ec, data.Bindings[position]).Equals(data.BigEs[i][position]) {
+		return errors.New("the nonces do not belong to the local party")
+	}
+	return nil
+}
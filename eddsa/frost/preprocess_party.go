@@ -0,0 +1,133 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*PreprocessParty)(nil)
+var _ fmt.Stringer = (*PreprocessParty)(nil)
+
+type (
+	// PreprocessParty makes a batch of nonces for FROST signing, before the messages are known. Every party of the
+	// batch must take part in each signature that uses it.
+	PreprocessParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp preprocessTempData
+		data PreprocessData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *PreprocessData
+	}
+
+	preprocessTempData struct {
+		preprocessRound1Messages []tss.ParsedMessage
+		batchSize                int
+	}
+)
+
+// NewPreprocessParty returns a party that makes `batchSize` pairs of nonces with the parties in `params`
+func NewPreprocessParty(
+	params *tss.Parameters,
+	batchSize int,
+	out chan<- tss.Message,
+	end chan<- *PreprocessData,
+) tss.Party {
+	if batchSize < 1 {
+		panic(errors.New("frost.NewPreprocessParty expected a batch size of at least 1"))
+	}
+	partyCount := len(params.Parties().IDs())
+	p := &PreprocessParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      preprocessTempData{},
+		data:      PreprocessData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.preprocessRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.batchSize = batchSize
+	p.data.Hidings = make([]*big.Int, batchSize)
+	p.data.Bindings = make([]*big.Int, batchSize)
+	p.data.Ks = params.Parties().IDs().Keys()
+	p.data.BigDs = make([][]*crypto.ECPoint, partyCount)
+	p.data.BigEs = make([][]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *PreprocessParty) FirstRound() tss.Round {
+	return &preprocessRound1{&preprocessBase{newRounds(p.params, PreprocessTaskName), &p.data, &p.temp, p.out, p.end}}
+}
+
+func (p *PreprocessParty) Start() *tss.Error {
+	return tss.BaseStart(p, PreprocessTaskName)
+}
+
+func (p *PreprocessParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, PreprocessTaskName)
+}
+
+func (p *PreprocessParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *PreprocessParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *PreprocessParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PreprocessRound1Message:
+		p.temp.preprocessRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *PreprocessParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *PreprocessParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
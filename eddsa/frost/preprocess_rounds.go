@@ -0,0 +1,123 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *preprocessRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 1. make the nonces d_k, e_k and their commitments D_k = d_k*G, E_k = e_k*G
+	ec := tss.EC("eddsa")
+	bigDs := make([]*crypto.ECPoint, round.temp.batchSize)
+	bigEs := make([]*crypto.ECPoint, round.temp.batchSize)
+	for k := range bigDs {
+		round.data.Hidings[k], round.data.Bindings[k] = randomNonce(), randomNonce()
+		bigDs[k] = crypto.ScalarBaseMult(ec, round.data.Hidings[k])
+		bigEs[k] = crypto.ScalarBaseMult(ec, round.data.Bindings[k])
+	}
+	round.data.BigDs[i], round.data.BigEs[i] = bigDs, bigEs
+
+	// 2. broadcast the commitments
+	round.out <- NewPreprocessRound1Message(round.PartyID(), bigDs, bigEs)
+	return nil
+}
+
+func (round *preprocessRound1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.preprocessRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *preprocessRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreprocessRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preprocessRound1) NextRound() tss.Round {
+	round.started = false
+	return &preprocessFinalization{round}
+}
+
+// ----- //
+
+func (round *preprocessFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.preprocessRound1Messages[j].Content().(*PreprocessRound1Message)
+		if len(r1msg.GetHidings()) != round.temp.batchSize {
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, fmt.Errorf("expected %d nonce commitments but got %d",
+				round.temp.batchSize, len(r1msg.GetHidings())))
+			continue
+		}
+		bigDs, bigEs, err := r1msg.UnmarshalCommitments()
+		if err != nil {
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		round.data.BigDs[j], round.data.BigEs[j] = bigDs, bigEs
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	round.end <- round.data
+	return nil
+}
+
+func (round *preprocessFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preprocessFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preprocessFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 1. z_i = d_i + e_i*rho_i + lambda_i*x_i*c
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	di, ei := round.preprocess.Hidings[round.temp.position], round.preprocess.Bindings[round.temp.position]
+	zi := modQ.Add(di, modQ.Mul(ei, round.temp.rhos[i]))
+	round.temp.zi = modQ.Add(zi, modQ.Mul(round.temp.wi, round.temp.c))
+
+	// 2. erase the nonces before z_i is revealed; a second signature with them would reveal x_i
+	round.preprocess.erase(round.temp.position)
+	round.temp.wi = nil
+
+	round.out <- NewSignRound1Message(round.PartyID(), round.temp.position, round.temp.zi)
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// prepare checks the preprocess data and computes the binding factors, the group commitment R and the challenge c
+// that every signer derives from the message and the commitments at the position
+func (round *round1) prepare() error {
+	Ps := round.Parties().IDs()
+	i, position := round.PartyID().Index, round.temp.position
+	if err := round.preprocess.check(Ps, i, position); err != nil {
+		return err
+	}
+	if round.key.Xi == nil || round.key.EDDSAPub == nil {
+		return errors.New("the key data has no secret share or public key")
+	}
+	ks := round.key.Ks
+	bigWs, err := signing.PrepareBigWs(ks, round.key.BigXj)
+	if err != nil {
+		return err
+	}
+	round.temp.bigWs = bigWs
+	round.temp.wi = signing.PrepareForSigning(i, len(Ps), round.key.Xi, ks)
+
+	round.temp.bigDs = make([]*crypto.ECPoint, len(Ps))
+	round.temp.bigEs = make([]*crypto.ECPoint, len(Ps))
+	for j := range Ps {
+		round.temp.bigDs[j] = round.preprocess.BigDs[j][position]
+		round.temp.bigEs[j] = round.preprocess.BigEs[j][position]
+	}
+	round.temp.rhos = bindingFactors(round.key.EDDSAPub, round.temp.m, ks, round.temp.bigDs, round.temp.bigEs)
+	if round.temp.bigR, err = groupCommitment(round.temp.rhos, round.temp.bigDs, round.temp.bigEs); err != nil {
+		return fmt.Errorf("position %d: %v", position, err)
+	}
+	round.temp.c = challenge(round.temp.bigR, round.key.EDDSAPub, round.temp.m)
+	return nil
+}
+
+// verifyZ checks the share z_j of the signature sent by Pj: z_j*G = Dj + rho_j*Ej + c*lambda_j*Xj
+func (round *round1) verifyZ(j int, zj *big.Int) bool {
+	ec := tss.EC("eddsa")
+	if zj.Cmp(ec.Params().N) >= 0 {
+		return false
+	}
+	expected, err := round.temp.bigDs[j].Add(round.temp.bigEs[j].ScalarMult(round.temp.rhos[j]))
+	if err != nil {
+		return false
+	}
+	if expected, err = expected.Add(round.temp.bigWs[j].ScalarMult(round.temp.c)); err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(ec, zj).Equals(expected)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	PreprocessTaskName = "eddsa-frost-preprocess"
+	TaskName           = "eddsa-frost-signing"
+)
+
+type (
+	rounds struct {
+		*tss.Parameters
+		ok       []bool // `ok` tracks parties which have been verified by Update()
+		started  bool
+		number   int
+		taskName string
+	}
+
+	preprocessBase struct {
+		*rounds
+		data *PreprocessData
+		temp *preprocessTempData
+		out  chan<- tss.Message
+		end  chan<- *PreprocessData
+	}
+	preprocessRound1 struct {
+		*preprocessBase
+	}
+	preprocessFinalization struct {
+		*preprocessRound1
+	}
+
+	base struct {
+		*rounds
+		key        *keygen.LocalPartySaveData
+		preprocess *PreprocessData
+		data       *signing.SignatureData
+		temp       *localTempData
+		out        chan<- tss.Message
+		end        chan<- *signing.SignatureData
+	}
+	round1 struct {
+		*base
+	}
+	finalization struct {
+		*round1
+	}
+)
+
+var (
+	_ tss.Round = (*preprocessRound1)(nil)
+	_ tss.Round = (*preprocessFinalization)(nil)
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*finalization)(nil)
+)
+
+func newRounds(params *tss.Parameters, taskName string) *rounds {
+	return &rounds{params, make([]bool, len(params.Parties().IDs())), false, 1, taskName}
+}
+
+// ----- //
+
+func (round *rounds) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *rounds) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *rounds) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *rounds) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *rounds) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, round.taskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *rounds) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// ContextString is the context string of the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591
+const ContextString = "FROST-ED25519-SHA512-v1"
+
+// serializeScalar returns the 32 byte little endian encoding of a scalar reduced modulo the group order
+func serializeScalar(s *big.Int) []byte {
+	return common.IntToLittleEndian(new(big.Int).Mod(s, tss.EC("eddsa").Params().N), 32)
+}
+
+// serializeElement returns the RFC 8032 encoding of a point
+func serializeElement(p *crypto.ECPoint) []byte {
+	return common.EncodeEdwardsPoint(p.X(), p.Y())
+}
+
+// hashToScalar reduces SHA-512(parts...) modulo the group order, reading the digest as little endian
+func hashToScalar(parts ...[]byte) *big.Int {
+	return new(big.Int).Mod(common.LittleEndianToInt(hash(parts...)), tss.EC("eddsa").Params().N)
+}
+
+func hash(parts ...[]byte) []byte {
+	h := sha512.New()
+	for _, part := range parts {
+		_, _ = h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// bindingFactors returns rho_j = H1(enc(A) || H4(msg) || H5(commitment list) || id_j) for each signer, where the
+// commitment list holds the identifier and the nonce commitments of every signer in order.
+func bindingFactors(pub *crypto.ECPoint, msg []byte, ks []*big.Int, bigDs, bigEs []*crypto.ECPoint) []*big.Int {
+	encCommitments := make([]byte, 0, 96*len(ks))
+	for j := range ks {
+		encCommitments = append(encCommitments, serializeScalar(ks[j])...)
+		encCommitments = append(encCommitments, serializeElement(bigDs[j])...)
+		encCommitments = append(encCommitments, serializeElement(bigEs[j])...)
+	}
+	prefix := serializeElement(pub)
+	prefix = append(prefix, hash([]byte(ContextString+"msg"), msg)...)
+	prefix = append(prefix, hash([]byte(ContextString+"com"), encCommitments)...)
+	rhos := make([]*big.Int, len(ks))
+	for j := range ks {
+		rhos[j] = hashToScalar([]byte(ContextString+"rho"), prefix, serializeScalar(ks[j]))
+	}
+	return rhos
+}
+
+// groupCommitment returns R = sum_j (Dj + rho_j*Ej)
+func groupCommitment(rhos []*big.Int, bigDs, bigEs []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	var R *crypto.ECPoint
+	for j := range rhos {
+		Rj, err := bigDs[j].Add(bigEs[j].ScalarMult(rhos[j]))
+		if err != nil {
+			return nil, err
+		}
+		if R == nil {
+			R = Rj
+			continue
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return nil, err
+		}
+	}
+	if R == nil || isIdentity(R) {
+		return nil, errors.New("the group commitment is the identity")
+	}
+	return R, nil
+}
+
+// challenge returns c = H2(enc(R) || enc(A) || msg), the challenge of an Ed25519 signature
+func challenge(R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	return hashToScalar(serializeElement(R), serializeElement(pub), msg)
+}
+
+// validCommitment checks that a nonce commitment is in the prime order subgroup and is not the identity
+func validCommitment(p *crypto.ECPoint) bool {
+	return p != nil && p.ValidateBasic() && !isIdentity(p) && p.EightInvEight().Equals(p)
+}
+
+func isIdentity(p *crypto.ECPoint) bool {
+	return p.X().Sign() == 0 && p.Y().Cmp(big.NewInt(1)) == 0
+}
+
+func randomNonce() *big.Int {
+	return common.GetRandomPositiveInt(tss.EC("eddsa").Params().N)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Child keys are derived without hardening, by adding a public tweak to the key as in BIP32 public derivation. The
+// child at index i < 2^31 of the key A with chain code c is:
+//
+//	I = HMAC-SHA512(Key = c, Data = 0x02 || enc(A) || ser32(i))
+//	t = parse256(I[0:32]) mod L
+//	A' = A + t*G, with chain code I[32:64]
+//
+// where enc(A) is the RFC 8032 encoding of A and ser32 and parse256 are big endian. As in BIP32, an index is invalid
+// when t is 0 or A' is the identity. Each secret share becomes x_j + t and each public share X_j + t*G; the
+// Lagrange coefficients of any signing set sum to 1, so the new shares share the secret a + t of A'.
+// Anyone who knows A and c can derive every child public key, and a child secret together with c reveals the parent
+// secret, which is why hardened indexes are not supported.
+
+const (
+	// ChainCodeLength is the length of the chain code agreed at keygen
+	ChainCodeLength = 32
+
+	// HardenedKeyStart is the first hardened index. Hardened derivation needs the whole secret, so it is not supported.
+	HardenedKeyStart = 0x80000000
+
+	chainCodeDomain = "eddsa-keygen-chain-code"
+)
+
+// DerivePublicKey returns the public key and chain code of the child at `path` of the key `pub` with `chainCode`
+func DerivePublicKey(pub *crypto.ECPoint, chainCode []byte, path []uint32) (*crypto.ECPoint, []byte, error) {
+	_, childPub, childChainCode, err := derivePath(pub, chainCode, path)
+	return childPub, childChainCode, err
+}
+
+// DeriveChild returns the save data of the child key at `path`. The secret share, the public shares, EDDSAPub and
+// ChainCode are those of the child; the parties and their indexes are the same. Use the result to sign with the child
+// key. The save data must have a ChainCode, which keygen agrees on.
+func (save LocalPartySaveData) DeriveChild(path []uint32) (LocalPartySaveData, error) {
+	tweak, childPub, childChainCode, err := derivePath(save.EDDSAPub, save.ChainCode, path)
+	if err != nil {
+		return LocalPartySaveData{}, err
+	}
+	ec := tss.EC("eddsa")
+	bigT := crypto.ScalarBaseMult(ec, tweak)
+	child := NewLocalPartySaveData(len(save.Ks))
+	child.ShareID = save.ShareID
+	if save.Xi != nil {
+		child.Xi = common.ModInt(ec.Params().N).Add(save.Xi, tweak)
+	}
+	for j := range save.Ks {
+		child.Ks[j] = save.Ks[j]
+		if save.BigXj[j] == nil {
+			return LocalPartySaveData{}, fmt.Errorf("BigXj[%d] is missing", j)
+		}
+		if child.BigXj[j], err = save.BigXj[j].Add(bigT); err != nil {
+			return LocalPartySaveData{}, err
+		}
+	}
+	child.EDDSAPub, child.ChainCode = childPub, childChainCode
+	return child, nil
+}
+
+// ----- //
+
+// derivePath returns the sum of the tweaks along `path` with the child public key and chain code
+func derivePath(pub *crypto.ECPoint, chainCode []byte, path []uint32) (*big.Int, *crypto.ECPoint, []byte, error) {
+	if pub == nil || !pub.ValidateBasic() {
+		return nil, nil, nil, errors.New("the public key is missing or not on the curve")
+	}
+	if len(chainCode) != ChainCodeLength {
+		return nil, nil, nil, fmt.Errorf("the chain code must be %d bytes, got %d", ChainCodeLength, len(chainCode))
+	}
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	tweak := big.NewInt(0)
+	for depth, index := range path {
+		t, childPub, childChainCode, err := deriveChild(pub, chainCode, index)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("path index %d: %v", depth, err)
+		}
+		tweak = modQ.Add(tweak, t)
+		pub, chainCode = childPub, childChainCode
+	}
+	return tweak, pub, append([]byte(nil), chainCode...), nil
+}
+
+func deriveChild(pub *crypto.ECPoint, chainCode []byte, index uint32) (*big.Int, *crypto.ECPoint, []byte, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, nil, fmt.Errorf("index %d is hardened; threshold keys only support non-hardened derivation", index)
+	}
+	ec := tss.EC("eddsa")
+	data := make([]byte, 1+32+4)
+	data[0] = 0x02
+	copy(data[1:33], common.EncodeEdwardsPoint(pub.X(), pub.Y()))
+	binary.BigEndian.PutUint32(data[33:], index)
+	mac := hmac.New(sha512.New, chainCode)
+	_, _ = mac.Write(data)
+	I := mac.Sum(nil)
+
+	tweak := new(big.Int).Mod(new(big.Int).SetBytes(I[:32]), ec.Params().N)
+	if tweak.Sign() == 0 {
+		return nil, nil, nil, fmt.Errorf("index %d is invalid; use the next index", index)
+	}
+	childPub, err := pub.Add(crypto.ScalarBaseMult(ec, tweak))
+	if err != nil || (childPub.X().Sign() == 0 && childPub.Y().Cmp(big.NewInt(1)) == 0) {
+		return nil, nil, nil, fmt.Errorf("index %d is invalid; use the next index", index)
+	}
+	return tweak, childPub, I[32:], nil
+}
+
+// combineChainCodes hashes the contribution of every party, in party order, into the chain code
+func combineChainCodes(chainCodeJs [][]byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(chainCodeDomain))
+	for _, chainCodeJ := range chainCodeJs {
+		_, _ = h.Write(chainCodeJ)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+var testPath = []uint32{44, 501, 0, HardenedKeyStart - 1}
+
+// referenceDerive derives the child secret of the single key `a` at `path` with the documented scheme
+func referenceDerive(a *big.Int, chainCode []byte, path []uint32) (*big.Int, []byte) {
+	L := tss.EC("eddsa").Params().N
+	for _, index := range path {
+		var data [37]byte
+		data[0] = 0x02
+		encA := referencePublicKey(a)
+		copy(data[1:33], encA[:])
+		binary.BigEndian.PutUint32(data[33:], index)
+		mac := hmac.New(sha512.New, chainCode)
+		_, _ = mac.Write(data[:])
+		I := mac.Sum(nil)
+		a = new(big.Int).Mod(new(big.Int).Add(a, new(big.Int).SetBytes(I[:32])), L)
+		chainCode = I[32:]
+	}
+	return a, chainCode
+}
+
+// referencePublicKey returns the RFC 8032 encoding of a*G
+func referencePublicKey(a *big.Int) [32]byte {
+	var le [32]byte
+	copy(le[:], common.IntToLittleEndian(a, 32))
+	var A edwards25519.ExtendedGroupElement
+	edwards25519.GeScalarMultBase(&A, &le)
+	var enc [32]byte
+	A.ToBytes(&enc)
+	return enc
+}
+
+func TestDerivePublicKey(t *testing.T) {
+	ec := tss.EC("eddsa")
+	a := common.GetRandomPositiveInt(ec.Params().N)
+	chainCode := common.GetRandomPositiveInt(chainCodeBound).FillBytes(make([]byte, ChainCodeLength))
+
+	childPub, childChainCode, err := DerivePublicKey(crypto.ScalarBaseMult(ec, a), chainCode, testPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	childA, expectedChainCode := referenceDerive(a, chainCode, testPath)
+	expectedPub := referencePublicKey(childA)
+	assert.Equal(t, expectedPub[:], common.EncodeEdwardsPoint(childPub.X(), childPub.Y()), "the child key must match the reference derivation")
+	assert.Equal(t, expectedChainCode, childChainCode)
+
+	// the path is applied one index at a time
+	midPub, midChainCode, err := DerivePublicKey(crypto.ScalarBaseMult(ec, a), chainCode, testPath[:2])
+	assert.NoError(t, err)
+	endPub, _, err := DerivePublicKey(midPub, midChainCode, testPath[2:])
+	assert.NoError(t, err)
+	assert.True(t, childPub.Equals(endPub))
+
+	_, _, err = DerivePublicKey(crypto.ScalarBaseMult(ec, a), chainCode, []uint32{HardenedKeyStart})
+	assert.Error(t, err, "hardened derivation must be rejected")
+	_, _, err = DerivePublicKey(crypto.ScalarBaseMult(ec, a), chainCode[1:], testPath)
+	assert.Error(t, err, "a short chain code must be rejected")
+}
+
+func TestDeriveChild(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	chainCode := common.GetRandomPositiveInt(chainCodeBound).FillBytes(make([]byte, ChainCodeLength))
+	children := make([]LocalPartySaveData, len(keys))
+	publicData := make([]LocalPartyPublicData, len(keys))
+	for i := range keys {
+		_, err := keys[i].DeriveChild(testPath)
+		assert.Error(t, err, "save data without a chain code cannot be derived")
+
+		keys[i].ChainCode = chainCode
+		children[i], err = keys[i].DeriveChild(testPath)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, children[i].Validate())
+		publicData[i] = children[i].PublicData()
+	}
+	assert.NoError(t, VerifyCommittee(publicData))
+
+	// every party derives the child public key that DerivePublicKey gives to a watch-only holder
+	childPub, childChainCode, err := DerivePublicKey(keys[0].EDDSAPub, chainCode, testPath)
+	assert.NoError(t, err)
+	assert.True(t, childPub.Equals(children[0].EDDSAPub))
+	assert.Equal(t, childChainCode, children[0].ChainCode)
+
+	// the child shares share the child secret of the reference derivation
+	a, childA := reconstruct(t, keys), reconstruct(t, children)
+	expectedA, _ := referenceDerive(a, chainCode, testPath)
+	assert.Equal(t, 0, expectedA.Cmp(childA), "the child secret must match the reference derivation")
+	assert.NotEqual(t, 0, a.Cmp(childA))
+}
+
+func reconstruct(t *testing.T, keys []LocalPartySaveData) *big.Int {
+	shares := make(vss.Shares, testThreshold+1)
+	for i := range shares {
+		shares[i] = &vss.Share{Threshold: testThreshold, ID: keys[i].ShareID, Share: keys[i].Xi}
+	}
+	secret, err := shares.ReConstruct("eddsa")
+	assert.NoError(t, err)
+	return secret
+}
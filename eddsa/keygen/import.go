@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// ImportSeed splits the Ed25519 key of the 32 byte RFC 8032 `seed` among `sortedIDs` with a threshold of
+// `threshold`, so that an existing key such as a Solana wallet can be moved into threshold custody. It returns the save
+// data of each party, in the order of `sortedIDs`, and the Feldman commitments `vs` of the sharing polynomial, whose
+// vs[0] is EDDSAPub. Hand each party its save data and `vs`, and have it check them with VerifyImportedShare.
+//
+// The secret scalar is the one of RFC 8032: the first half of SHA-512(seed), clamped. Threshold signatures verify
+// under the public key of the seed but are not the deterministic signatures of crypto/ed25519, as the nonces are
+// random. The dealer sees the whole key, so the seed must be destroyed once the shares are handed out. The chain code
+// for DeriveChild is random.
+func ImportSeed(seed []byte, threshold int, sortedIDs tss.SortedPartyIDs) ([]LocalPartySaveData, vss.Vs, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, nil, fmt.Errorf("ImportSeed: the seed must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	if threshold < 1 || len(sortedIDs) < threshold+1 {
+		return nil, nil, fmt.Errorf("ImportSeed: t+1=%d is not satisfied by the party count of %d",
+			threshold+1, len(sortedIDs))
+	}
+	ec := tss.EC("eddsa")
+	secret := seedToScalar(seed)
+	if secret.Sign() == 0 {
+		return nil, nil, errors.New("ImportSeed: the seed gives a zero scalar")
+	}
+
+	ks := make([]*big.Int, len(sortedIDs))
+	for j, id := range sortedIDs {
+		ks[j] = id.KeyInt()
+	}
+	vs, shares, err := vss.Create("eddsa", threshold, secret, ks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ImportSeed: %v", err)
+	}
+	pub := vs[0]
+	if !bytes.Equal(common.EncodeEdwardsPoint(pub.X(), pub.Y()), ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)) {
+		return nil, nil, errors.New("ImportSeed: the shared key does not match the public key of the seed")
+	}
+
+	chainCode := common.GetRandomPositiveInt(chainCodeBound).FillBytes(make([]byte, ChainCodeLength))
+	bigXj := make([]*crypto.ECPoint, len(shares))
+	for j, share := range shares {
+		bigXj[j] = crypto.ScalarBaseMult(ec, share.Share)
+	}
+	saves := make([]LocalPartySaveData, len(sortedIDs))
+	for i, share := range shares {
+		save := NewLocalPartySaveData(len(sortedIDs))
+		save.Xi, save.ShareID = share.Share, share.ID
+		copy(save.Ks, ks)
+		copy(save.BigXj, bigXj)
+		save.EDDSAPub = pub
+		save.ChainCode = append([]byte(nil), chainCode...)
+		saves[i] = save
+	}
+	return saves, vs, nil
+}
+
+// VerifyImportedShare checks the save data that a party received from ImportSeed against the commitments `vs`: the
+// secret share lies on the committed polynomial of degree `threshold`, EDDSAPub is vs[0] and every BigXj is the
+// commitment evaluated at its Kj. Every party should call it before accepting its save data.
+func VerifyImportedShare(save LocalPartySaveData, threshold int, vs vss.Vs) error {
+	if len(vs) != threshold+1 {
+		return fmt.Errorf("VerifyImportedShare: expected %d commitments, got %d", threshold+1, len(vs))
+	}
+	for c, v := range vs {
+		if v == nil || !v.ValidateBasic() {
+			return fmt.Errorf("VerifyImportedShare: commitment %d is missing or not on the curve", c)
+		}
+	}
+	if err := save.Validate(); err != nil {
+		return fmt.Errorf("VerifyImportedShare: %v", err)
+	}
+	if !save.EDDSAPub.Equals(vs[0]) {
+		return errors.New("VerifyImportedShare: EDDSAPub does not match the commitments")
+	}
+	share := vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi}
+	if !share.Verify("eddsa", threshold, vs) {
+		return errors.New("VerifyImportedShare: Xi does not match the commitments")
+	}
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	for j, kj := range save.Ks {
+		// Xj = sum_c vs[c] * kj^c
+		Xj, z := vs[0], big.NewInt(1)
+		for c := 1; c <= threshold; c++ {
+			z = modQ.Mul(z, kj)
+			var err error
+			if Xj, err = Xj.Add(vs[c].ScalarMult(z)); err != nil {
+				return fmt.Errorf("VerifyImportedShare: %v", err)
+			}
+		}
+		if !Xj.Equals(save.BigXj[j]) {
+			return fmt.Errorf("VerifyImportedShare: BigXj[%d] does not match the commitments", j)
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// seedToScalar returns the secret scalar of RFC 8032 for `seed`: SHA-512(seed)[0:32] clamped, as a little endian
+// integer reduced modulo the group order
+func seedToScalar(seed []byte) *big.Int {
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	return new(big.Int).Mod(common.LittleEndianToInt(h[:32]), tss.EC("eddsa").Params().N)
+}
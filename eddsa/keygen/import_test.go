@@ -0,0 +1,69 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func TestImportSeed(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	pIDs := tss.GenerateTestPartyIDs(5)
+	threshold := 2
+
+	saves, vs, err := ImportSeed(seed, threshold, pIDs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, saves, len(pIDs))
+	assert.Len(t, vs, threshold+1)
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	for i, save := range saves {
+		assert.Equal(t, []byte(pub), common.EncodeEdwardsPoint(save.EDDSAPub.X(), save.EDDSAPub.Y()), "EDDSAPub must be the public key of the seed")
+		assert.Equal(t, pIDs[i].KeyInt(), save.ShareID)
+		assert.Len(t, save.ChainCode, ChainCodeLength)
+		assert.NoError(t, VerifyImportedShare(save, threshold, vs))
+		th, err := save.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, threshold, th)
+	}
+
+	// any t+1 shares give back the clamped scalar of the seed
+	shares := make(vss.Shares, 0, threshold+1)
+	for _, save := range saves[1 : threshold+2] {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+	}
+	secret, err := shares.ReConstruct("eddsa")
+	assert.NoError(t, err)
+	assert.Equal(t, seedToScalar(seed), secret)
+
+	// a share or commitments that were tampered with are rejected
+	bad := saves[0]
+	bad.Xi = new(big.Int).Add(bad.Xi, big.NewInt(1))
+	assert.Error(t, VerifyImportedShare(bad, threshold, vs))
+	otherSaves, otherVs, err := ImportSeed(seed, threshold, pIDs)
+	assert.NoError(t, err)
+	assert.Error(t, VerifyImportedShare(saves[0], threshold, otherVs))
+	assert.NoError(t, VerifyImportedShare(otherSaves[0], threshold, otherVs))
+	assert.Error(t, VerifyImportedShare(saves[0], threshold+1, vs))
+
+	_, _, err = ImportSeed(seed[:31], threshold, pIDs)
+	assert.Error(t, err)
+	_, _, err = ImportSeed(seed, len(pIDs), pIDs)
+	assert.Error(t, err)
+}
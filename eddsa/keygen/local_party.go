@@ -51,6 +51,7 @@ type (
 		vs            vss.Vs
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+		chainCodeI    []byte // this party's contribution to the chain code
 	}
 )
 
@@ -194,6 +194,12 @@ keygen:
 				}
 				t.Log("Public key distribution test done.")
 
+				// make sure everyone has the same chain code
+				assert.Len(t, save.ChainCode, ChainCodeLength)
+				for _, Pj := range parties {
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode)
+				}
+
 				// test sign/verify
 				data := make([]byte, 32)
 				for i := range data {
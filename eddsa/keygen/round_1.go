@@ -18,7 +18,8 @@ import (
 )
 
 var (
-	zero = big.NewInt(0)
+	zero           = big.NewInt(0)
+	chainCodeBound = new(big.Int).Lsh(big.NewInt(1), 8*ChainCodeLength)
 )
 
 // round 1 represents round 1 of the keygen part of the EDDSA TSS spec
@@ -54,12 +55,13 @@ func (round *round1) Start() *tss.Error {
 	ui = zero // clears the secret data from memory
 	_ = ui    // silences a linter warning
 
-	// 3. make commitment -> (C, D)
+	// 3. make commitment -> (C, D) to the poly*G followed by this party's contribution to the chain code
 	pGFlat, err := crypto.FlattenECPoints(vs)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
-	cmt := cmts.NewHashCommitment(pGFlat...)
+	chainCodeI := common.GetRandomPositiveInt(chainCodeBound).FillBytes(make([]byte, ChainCodeLength))
+	cmt := cmts.NewHashCommitment(append(pGFlat, new(big.Int).SetBytes(chainCodeI))...)
 
 	// for this P: SAVE
 	// - shareID
@@ -71,6 +73,7 @@ func (round *round1) Start() *tss.Error {
 	round.temp.shares = shares
 
 	round.temp.deCommitPolyG = cmt.D
+	round.temp.chainCodeI = chainCodeI
 
 	// BROADCAST commitments
 	{
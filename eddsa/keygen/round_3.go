@@ -53,6 +53,7 @@ func (round *round3) Start() *tss.Error {
 	type vssOut struct {
 		unWrappedErr error
 		pjVs         vss.Vs
+		chainCodeJ   []byte
 	}
 	chs := make([]chan vssOut, len(Ps))
 	for i := range chs {
@@ -74,25 +75,32 @@ func (round *round3) Start() *tss.Error {
 			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
 			ok, flatPolyGs := cmtDeCmt.DeCommit()
 			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+				ch <- vssOut{errors.New("de-commitment verify failed"), nil, nil}
 				return
 			}
-			PjVs, err := crypto.UnFlattenECPoints(tss.EC("eddsa"), flatPolyGs)
+			// the poly*G are followed by Pj's contribution to the chain code
+			last := len(flatPolyGs) - 1
+			if last%2 != 0 || flatPolyGs[last].BitLen() > 8*ChainCodeLength {
+				ch <- vssOut{errors.New("the de-commitment does not end with a chain code"), nil, nil}
+				return
+			}
+			chainCodeJ := flatPolyGs[last].FillBytes(make([]byte, ChainCodeLength))
+			PjVs, err := crypto.UnFlattenECPoints(tss.EC("eddsa"), flatPolyGs[:last])
 			for i, PjV := range PjVs {
 				PjVs[i] = PjV.EightInvEight()
 			}
 			if err != nil {
-				ch <- vssOut{err, nil}
+				ch <- vssOut{err, nil, nil}
 				return
 			}
 			proof, err := r2msg2.UnmarshalZKProof()
 			if err != nil {
-				ch <- vssOut{errors.New("failed to unmarshal zk proof"), nil}
+				ch <- vssOut{errors.New("failed to unmarshal zk proof"), nil, nil}
 				return
 			}
 			ok = proof.Verify("eddsa", PjVs[0])
 			if !ok {
-				ch <- vssOut{errors.New("failed to prove zk proof"), nil}
+				ch <- vssOut{errors.New("failed to prove zk proof"), nil, nil}
 				return
 			}
 			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
@@ -102,11 +110,11 @@ func (round *round3) Start() *tss.Error {
 				Share:     r2msg1.UnmarshalShare(),
 			}
 			if ok = PjShare.Verify("eddsa", round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
+				ch <- vssOut{errors.New("vss verify failed"), nil, nil}
 				return
 			}
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{nil, PjVs, chainCodeJ}
 		}(j, chs[j])
 	}
 
@@ -189,6 +197,17 @@ func (round *round3) Start() *tss.Error {
 	}
 	round.save.EDDSAPub = eddsaPubKey
 
+	// 19. compute and SAVE the chain code from the contributions of every Pj
+	chainCodeJs := make([][]byte, len(Ps))
+	for j := range Ps {
+		if j == PIdx {
+			chainCodeJs[j] = round.temp.chainCodeI
+			continue
+		}
+		chainCodeJs[j] = vssResults[j].chainCodeJ
+	}
+	round.save.ChainCode = combineChainCodes(chainCodeJs)
+
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
 
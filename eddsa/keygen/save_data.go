@@ -8,10 +8,14 @@ package keygen
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"math/big"
 
 	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/envelope"
 	"github.com/sisu-network/tss-lib/tss"
 )
 
@@ -33,6 +37,9 @@ type (
 
 		// the EdDSA public key
 		EDDSAPub *crypto.ECPoint // y
+
+		// the chain code agreed at keygen, for deriving child keys with DeriveChild
+		ChainCode []byte `json:",omitempty"`
 	}
 )
 
@@ -51,6 +58,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData := NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
+	newData.ChainCode = sourceData.ChainCode
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -61,3 +69,36 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// SaveDataContentType identifies eddsa keygen save data inside an encrypted envelope
+const SaveDataContentType = "eddsa-keygen-save-data"
+
+// SaveLocalPartySaveData encrypts `data` under `secret` and writes it to `w` as a versioned envelope.
+// Use this instead of plain JSON, as the save data holds the secret share Xi.
+func SaveLocalPartySaveData(w io.Writer, data LocalPartySaveData, secret envelope.Secret) error {
+	bz, err := json.Marshal(&data)
+	if err != nil {
+		return err
+	}
+	sealed, err := envelope.Seal(SaveDataContentType, bz, secret)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(sealed)
+	return err
+}
+
+// LoadLocalPartySaveData reads an envelope written by SaveLocalPartySaveData and decrypts it with `secret`.
+// It refuses unknown envelope versions and data that was tampered with.
+func LoadLocalPartySaveData(r io.Reader, secret envelope.Secret) (data LocalPartySaveData, err error) {
+	sealed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return
+	}
+	bz, err := envelope.Open(SaveDataContentType, sealed, secret)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bz, &data)
+	return
+}
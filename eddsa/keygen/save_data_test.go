@@ -0,0 +1,48 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/crypto/envelope"
+)
+
+func TestSaveLoadLocalPartySaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	secret := envelope.PassphraseWithParams([]byte("passphrase"), envelope.ScryptParams{N: 1 << 10, R: 8, P: 1})
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, SaveLocalPartySaveData(buf, keys[0], secret))
+	sealed := buf.Bytes()
+	assert.NotContains(t, string(sealed), keys[0].Xi.String())
+
+	loaded, err := LoadLocalPartySaveData(bytes.NewReader(sealed), secret)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, keys[0].Xi.Cmp(loaded.Xi))
+	assert.Equal(t, 0, keys[0].ShareID.Cmp(loaded.ShareID))
+	assert.True(t, keys[0].EDDSAPub.Equals(loaded.EDDSAPub))
+	assert.Len(t, loaded.BigXj, len(keys[0].BigXj))
+
+	_, err = LoadLocalPartySaveData(bytes.NewReader(sealed), envelope.Passphrase([]byte("wrong")))
+	assert.ErrorIs(t, err, envelope.ErrDecryptionFailed)
+	_, err = LoadLocalPartySaveData(bytes.NewReader(sealed), envelope.Key(make([]byte, envelope.KeyLen)))
+	assert.ErrorIs(t, err, envelope.ErrWrongSecretKind)
+
+	// an envelope of another kind of data must not load as save data, even under the right secret
+	bz, err := json.Marshal(&keys[0])
+	assert.NoError(t, err)
+	other, err := envelope.Seal("ecdsa-keygen-save-data", bz, secret)
+	assert.NoError(t, err)
+	_, err = LoadLocalPartySaveData(bytes.NewReader(other), secret)
+	assert.ErrorIs(t, err, envelope.ErrWrongContentType)
+}
@@ -0,0 +1,203 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+type (
+	// LocalPartyPublicData is the part of LocalPartySaveData that may be shared with the other parties,
+	// e.g. to cross-check the committee with VerifyCommittee.
+	LocalPartyPublicData struct {
+		ShareID *big.Int
+
+		Ks    []*big.Int
+		BigXj []*crypto.ECPoint
+
+		EDDSAPub  *crypto.ECPoint
+		ChainCode []byte `json:",omitempty"`
+	}
+)
+
+// PublicData returns the public part of the save data.
+func (save LocalPartySaveData) PublicData() LocalPartyPublicData {
+	return LocalPartyPublicData{
+		ShareID:   save.ShareID,
+		Ks:        save.Ks,
+		BigXj:     save.BigXj,
+		EDDSAPub:  save.EDDSAPub,
+		ChainCode: save.ChainCode,
+	}
+}
+
+// Validate checks that the save data is internally consistent: the public data passes its own Validate()
+// and Xi*G equals this party's BigXj.
+func (save LocalPartySaveData) Validate() error {
+	if err := save.PublicData().Validate(); err != nil {
+		return err
+	}
+	ec := tss.EC("eddsa")
+	if save.Xi == nil || save.Xi.Sign() <= 0 || save.Xi.Cmp(ec.Params().N) >= 0 {
+		return errors.New("Xi is missing or out of range")
+	}
+	if !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[save.PublicData().selfIndex()]) {
+		return errors.New("Xi*G does not match BigXj for this party")
+	}
+	return nil
+}
+
+// Validate checks that the public data is well-formed: the Ks are distinct and non-zero and every BigXj lies on
+// the same polynomial of degree t whose t+1 point interpolation gives EDDSAPub.
+func (data LocalPartyPublicData) Validate() error {
+	if data.EDDSAPub == nil || !data.EDDSAPub.ValidateBasic() {
+		return errors.New("EDDSAPub is missing or not on the curve")
+	}
+	if data.ChainCode != nil && len(data.ChainCode) != ChainCodeLength {
+		return fmt.Errorf("ChainCode must be %d bytes, got %d", ChainCodeLength, len(data.ChainCode))
+	}
+	ec := tss.EC("eddsa")
+	n := len(data.Ks)
+	if n < 2 {
+		return errors.New("the save data must hold at least two parties")
+	}
+	if len(data.BigXj) != n {
+		return errors.New("BigXj does not have the same length as Ks")
+	}
+	for j, kj := range data.Ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is missing", j)
+		}
+	}
+	if _, err := vss.CheckIndexes(ec, data.Ks); err != nil {
+		return err
+	}
+	if data.ShareID == nil || data.selfIndex() < 0 {
+		return errors.New("ShareID was not found in Ks")
+	}
+	for j, Xj := range data.BigXj {
+		if Xj == nil || !Xj.ValidateBasic() {
+			return fmt.Errorf("BigXj[%d] is missing or not on the curve", j)
+		}
+	}
+	_, err := data.Threshold()
+	return err
+}
+
+// Threshold recovers the threshold t from the public shares: it is the smallest t for which the interpolation
+// of the first t+1 BigXj gives EDDSAPub. An error is returned if no such t exists or if any of the remaining
+// BigXj does not lie on the same polynomial.
+func (data LocalPartyPublicData) Threshold() (int, error) {
+	for t := 1; t < len(data.Ks); t++ {
+		y, err := vss.InterpolatePoint("eddsa", data.Ks[:t+1], data.BigXj[:t+1], zero)
+		if err != nil {
+			return 0, err
+		}
+		if !y.Equals(data.EDDSAPub) {
+			continue
+		}
+		for j := t + 1; j < len(data.Ks); j++ {
+			Xj, err := vss.InterpolatePoint("eddsa", data.Ks[:t+1], data.BigXj[:t+1], data.Ks[j])
+			if err != nil {
+				return 0, err
+			}
+			if !Xj.Equals(data.BigXj[j]) {
+				return 0, fmt.Errorf("BigXj[%d] is not consistent with the other public shares", j)
+			}
+		}
+		return t, nil
+	}
+	return 0, errors.New("no t+1 BigXj interpolate to EDDSAPub")
+}
+
+// VerifyCommittee checks that the public data of every party in a committee is valid and that all of the parties
+// agree on the key and the public shares. Each party must appear once.
+func VerifyCommittee(parties []LocalPartyPublicData) error {
+	if len(parties) == 0 {
+		return errors.New("VerifyCommittee: no parties were given")
+	}
+	ref := parties[0]
+	if err := ref.Validate(); err != nil {
+		return fmt.Errorf("VerifyCommittee: party 0: %v", err)
+	}
+	if len(parties) != len(ref.Ks) {
+		return fmt.Errorf("VerifyCommittee: expected %d parties but got %d", len(ref.Ks), len(parties))
+	}
+	refIndex := make(map[string]int, len(ref.Ks))
+	for j, kj := range ref.Ks {
+		refIndex[hex.EncodeToString(kj.Bytes())] = j
+	}
+
+	var errs error
+	seen := make(map[string]struct{}, len(parties))
+	for p, data := range parties {
+		if data.ShareID == nil {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID is missing", p))
+			continue
+		}
+		id := hex.EncodeToString(data.ShareID.Bytes())
+		if _, found := seen[id]; found {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID appears more than once", p))
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, found := refIndex[id]; !found {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: ShareID is not in the committee", p))
+			continue
+		}
+		if err := data.agreesWith(ref, refIndex); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("party %d: %v", p, err))
+		}
+	}
+	return errs
+}
+
+// ----- //
+
+func (data LocalPartyPublicData) selfIndex() int {
+	for j, kj := range data.Ks {
+		if kj != nil && data.ShareID != nil && kj.Cmp(data.ShareID) == 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+func (data LocalPartyPublicData) agreesWith(ref LocalPartyPublicData, refIndex map[string]int) error {
+	if data.EDDSAPub == nil || !data.EDDSAPub.Equals(ref.EDDSAPub) {
+		return errors.New("EDDSAPub differs")
+	}
+	if !bytes.Equal(data.ChainCode, ref.ChainCode) {
+		return errors.New("ChainCode differs")
+	}
+	if len(data.Ks) != len(ref.Ks) || len(data.BigXj) != len(ref.Ks) {
+		return errors.New("the number of parties differs")
+	}
+	for j, kj := range data.Ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is missing", j)
+		}
+		r, found := refIndex[hex.EncodeToString(kj.Bytes())]
+		if !found {
+			return fmt.Errorf("Ks[%d] is not in the committee", j)
+		}
+		if data.BigXj[j] == nil || !data.BigXj[j].Equals(ref.BigXj[r]) {
+			return fmt.Errorf("BigXj differs for Ks[%d]", j)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/test"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func TestReconstructKey(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	sk, err := ReconstructKey(testThreshold, keys[:testThreshold+1])
+	assert.NoError(t, err)
+	pkX, pkY := sk.Public()
+	assert.Equal(t, keys[0].EDDSAPub.X(), pkX)
+	assert.Equal(t, keys[0].EDDSAPub.Y(), pkY)
+
+	sk2, err := ReconstructKey(testThreshold, keys[testParticipants-testThreshold-1:])
+	assert.NoError(t, err)
+	assert.Equal(t, sk.GetD(), sk2.GetD())
+	assert.Len(t, MarshalPrivateKey(sk), PrivateKeyBytesLen)
+
+	// signatures made with the recovered scalar verify under crypto/ed25519
+	msg := []byte("recovery")
+	sig, err := sk.Sign(msg)
+	assert.NoError(t, err)
+	pub := ed25519.PublicKey(edwards.NewPublicKey(pkX, pkY).Serialize())
+	assert.True(t, ed25519.Verify(pub, msg, sig.Serialize()))
+}
+
+func TestReconstructKeyBelowThreshold(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	_, err = ReconstructKey(testThreshold, keys[:testThreshold])
+	assert.Error(t, err)
+}
+
+func TestReconstructKeyTamperedShare(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	keys[0].Xi = new(big.Int).Add(keys[0].Xi, big.NewInt(1))
+	_, err = ReconstructKey(testThreshold, keys[:testThreshold+1])
+	assert.Error(t, err, "a tampered share must not count towards t+1")
+
+	_, err = ReconstructKey(testThreshold, keys[:testThreshold+2])
+	assert.NoError(t, err)
+}
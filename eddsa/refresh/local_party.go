@@ -0,0 +1,196 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package refresh implements proactive share refresh for EdDSA keys: every party of the committee deals a
+// Feldman sharing of zero and adds the shares it receives to its own, so that all shares are re-randomised while
+// the committee, the threshold and the public key stay the same. It takes three rounds and no proofs beyond the
+// Feldman commitments, compared with five rounds for eddsa/resharing.
+//
+// NewThresholdChangeParty runs the same three rounds to move the key to a new threshold within the same committee:
+// each party re-deals its Lagrange-weighted share wi = lambda_i*xi with a polynomial of the new degree, and the
+// receivers check that the committed free term of Pj is lambda_j*Xj, so that the re-dealt key stays the same.
+//
+// NewRemovalParty runs the refresh among a strict subset of at least t+1 parties of the key and drops the others from
+// the save data. The shares of the removed parties stay on the old polynomial, so they no longer combine with the
+// refreshed ones.
+package refresh
+
+import (
+	"fmt"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rfRound1Messages,
+		rfRound2Message1s,
+		rfRound2Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after refresh)
+		KGCs          []cmt.HashCommitment
+		vs            vss.Vs
+		shares        vss.Shares
+		deCommitPolyG cmt.HashDeCommitment
+
+		// set by NewRemovalParty; the parties of the key that are not in the parameters are dropped
+		removal bool
+		// set by NewThresholdChangeParty, which checks that it is within [1, n-1]; 0 when the refresh keeps the
+		// threshold
+		newThreshold int
+		// lambda_j*Xj of each party, the expected free term of its re-dealt polynomial
+		bigWs []*crypto.ECPoint
+	}
+)
+
+// Exported, used in `tss` client
+// `params` must contain every party of the key and its threshold. The parties' keys are matched against `key.Ks`.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		save:      keygen.NewLocalPartySaveData(partyCount),
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.rfRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	return p
+}
+
+// NewThresholdChangeParty returns a party that moves the key from the threshold in `params` to `newThreshold`,
+// keeping the committee and the public key. Every party of the key must take part, as for NewLocalParty, and the
+// output save data only combines with that of the other parties of the same run.
+func NewThresholdChangeParty(
+	params *tss.Parameters,
+	newThreshold int,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	if newThreshold < 1 || params.PartyCount() <= newThreshold {
+		panic(fmt.Errorf("refresh.NewThresholdChangeParty expected a new threshold within [1, %d] but got %d",
+			params.PartyCount()-1, newThreshold))
+	}
+	p := NewLocalParty(params, key, out, end).(*LocalParty)
+	p.temp.newThreshold = newThreshold
+	return p
+}
+
+// NewRemovalParty returns a party that refreshes the key among the parties in `params` and drops the other parties of
+// the key, whose shares no longer combine with the refreshed ones. At least t+1 parties of the key must take part,
+// and the threshold in `params` is that of the key.
+func NewRemovalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+) tss.Party {
+	p := NewLocalParty(params, key, out, end).(*LocalParty)
+	p.temp.removal = true
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RefreshRound1Message:
+		p.temp.rfRound1Messages[fromPIdx] = msg
+	case *RefreshRound2Message1:
+		p.temp.rfRound2Message1s[fromPIdx] = msg
+	case *RefreshRound2Message2:
+		p.temp.rfRound2Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
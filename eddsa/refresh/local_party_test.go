@@ -0,0 +1,273 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/recovery"
+	. "github.com/sisu-network/tss-lib/eddsa/refresh"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldSK, err := recovery.ReconstructKey(testThreshold, oldKeys)
+	assert.NoError(t, err)
+
+	// the refresh zeroes the old Xi once it is done, so hand the parties their own copies
+	inputs, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	chainCode := make([]byte, keygen.ChainCodeLength)
+	chainCode[0] = 0x2a
+	for j := range inputs {
+		inputs[j].ChainCode = chainCode
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), testThreshold)
+		P := NewLocalParty(params, inputs[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := runRefresh(t, parties, errCh, outCh, endCh)
+	if newKeys == nil {
+		return
+	}
+
+	pubs := make([]keygen.LocalPartyPublicData, len(newKeys))
+	for j, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.True(t, key.EDDSAPub.Equals(oldKeys[0].EDDSAPub), "the public key must not change")
+		assert.Equal(t, chainCode, key.ChainCode, "the chain code must not change")
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "the share must change")
+		assert.Zero(t, inputs[j].Xi.Sign(), "the old share must be zeroed")
+		pubs[j] = key.PublicData()
+	}
+	assert.NoError(t, keygen.VerifyCommittee(pubs))
+
+	// any t+1 refreshed shares still give the same secret
+	newSK, err := recovery.ReconstructKey(testThreshold, newKeys[testParticipants-testThreshold-1:])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldSK.GetD().Cmp(newSK.GetD()))
+
+	// old and new shares do not combine
+	mixed := append(append([]keygen.LocalPartySaveData{}, oldKeys[:testThreshold]...), newKeys[testThreshold])
+	mixed[testThreshold].BigXj = oldKeys[testThreshold].BigXj
+	_, err = recovery.ReconstructKey(testThreshold, mixed)
+	assert.Error(t, err)
+}
+
+func TestNewThresholdChangePartyRejectsBadThreshold(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+
+	// a new threshold of 0 must not fall back to a plain refresh, and n parties cannot hold a threshold of n
+	for _, bad := range []int{0, len(pIDs)} {
+		assert.Panics(t, func() { NewThresholdChangeParty(params, bad, keys[0], nil, nil) },
+			"a new threshold of %d must be refused", bad)
+	}
+}
+
+func TestE2EThresholdChange(t *testing.T) {
+	setUp("info")
+
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldSK, err := recovery.ReconstructKey(testThreshold, oldKeys)
+	assert.NoError(t, err)
+	inputs, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	newThreshold := testThreshold + 1
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), testThreshold)
+		P := NewThresholdChangeParty(params, newThreshold, inputs[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	newKeys := runRefresh(t, parties, errCh, outCh, endCh)
+	if newKeys == nil {
+		return
+	}
+
+	pubs := make([]keygen.LocalPartyPublicData, len(newKeys))
+	for j, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.True(t, key.EDDSAPub.Equals(oldKeys[0].EDDSAPub), "the public key must not change")
+		threshold, err := key.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, newThreshold, threshold)
+		assert.Zero(t, inputs[j].Xi.Sign(), "the old share must be zeroed")
+		pubs[j] = key.PublicData()
+	}
+	assert.NoError(t, keygen.VerifyCommittee(pubs))
+
+	// t+2 shares give the same secret, t+1 no longer do
+	newSK, err := recovery.ReconstructKey(newThreshold, newKeys[:newThreshold+1])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldSK.GetD().Cmp(newSK.GetD()))
+	_, err = recovery.ReconstructKey(testThreshold, newKeys[:testThreshold+1])
+	assert.Error(t, err)
+}
+
+func TestE2ERemoveParty(t *testing.T) {
+	setUp("info")
+
+	oldKeys, allPIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldSK, err := recovery.ReconstructKey(testThreshold, oldKeys)
+	assert.NoError(t, err)
+	inputs, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the last party is removed; the others refresh without it
+	pIDs := allPIDs[:len(allPIDs)-1]
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), testThreshold)
+		P := NewRemovalParty(params, inputs[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	newKeys := runRefresh(t, parties, errCh, outCh, endCh)
+	if newKeys == nil {
+		return
+	}
+
+	pubs := make([]keygen.LocalPartyPublicData, len(newKeys))
+	for j, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.Len(t, key.Ks, len(pIDs), "the removed party must be dropped")
+		assert.True(t, key.EDDSAPub.Equals(oldKeys[0].EDDSAPub), "the public key must not change")
+		threshold, err := key.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, testThreshold, threshold)
+		pubs[j] = key.PublicData()
+	}
+	assert.NoError(t, keygen.VerifyCommittee(pubs))
+
+	newSK, err := recovery.ReconstructKey(testThreshold, newKeys[:testThreshold+1])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldSK.GetD().Cmp(newSK.GetD()))
+
+	// the share of the removed party no longer combines with the refreshed ones
+	mixed := append(append([]keygen.LocalPartySaveData{}, newKeys[:testThreshold]...), oldKeys[len(allPIDs)-1])
+	_, err = recovery.ReconstructKey(testThreshold, mixed)
+	assert.Error(t, err)
+
+	// a removal needs t+1 remaining parties
+	few := allPIDs[:testThreshold]
+	fewCtx := tss.NewPeerContext(few)
+	P := NewRemovalParty(tss.NewParameters(fewCtx, few[0], len(few), testThreshold), oldKeys[0], outCh, endCh)
+	assert.NotNil(t, P.Start(), "t parties must not be able to remove the others")
+}
+
+// runRefresh routes the messages of the started `parties` and returns their save data in the order of the parties,
+// or nil after failing the test.
+func runRefresh(
+	t *testing.T,
+	parties []*LocalParty,
+	errCh chan *tss.Error,
+	outCh chan tss.Message,
+	endCh chan keygen.LocalPartySaveData,
+) []keygen.LocalPartySaveData {
+	updater := test.SharedPartyUpdater
+	newKeys := make([]keygen.LocalPartySaveData, len(parties))
+	var ended int32
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			if atomic.AddInt32(&ended, 1) == int32(len(parties)) {
+				t.Logf("Refresh done. Refreshed %d participants", ended)
+				return newKeys
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	cmts "github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. every party of the key takes part, in the order of the sorted party IDs, or only the remaining t+1 or more
+	// parties when the others are removed
+	if err := checkCommittee(round.input.Ks, round.Parties().IDs(), round.temp.removal); err != nil {
+		return round.WrapError(err, Pi)
+	}
+	if round.temp.removal && round.PartyCount() <= round.Threshold() {
+		return round.WrapError(fmt.Errorf("a removal needs t+1=%d remaining parties but got %d",
+			round.Threshold()+1, round.PartyCount()), Pi)
+	}
+	*round.input = keygen.BuildLocalSaveDataSubset(*round.input, round.Parties().IDs())
+	if round.input.ShareID == nil || round.input.ShareID.Cmp(Pi.KeyInt()) != 0 {
+		return round.WrapError(errors.New("the save data does not belong to this party"), Pi)
+	}
+	if t, err := round.input.PublicData().Threshold(); err != nil || t != round.Threshold() {
+		return round.WrapError(fmt.Errorf("the threshold of the save data does not match t=%d", round.Threshold()), Pi)
+	}
+
+	// 2. compute the vss shares of zero, or of wi = lambda_i*xi under the new threshold
+	ids := round.Parties().IDs().Keys()
+	var vs vss.Vs
+	var shares vss.Shares
+	var err error
+	if round.redeal() {
+		if round.temp.bigWs, err = signing.PrepareBigWs(round.input.Ks, round.input.BigXj); err != nil {
+			return round.WrapError(err, Pi)
+		}
+		wi := signing.PrepareForSigning(i, len(ids), round.input.Xi, round.input.Ks)
+		vs, shares, err = vss.Create("eddsa", round.temp.newThreshold, wi, ids)
+	} else {
+		vs, shares, err = vss.CreateZeroShares("eddsa", round.Threshold(), ids)
+	}
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 3. make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(pGFlat...)
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitment; round 1 message
+	r1msg := NewRefreshRound1Message(Pi, cmt.C)
+	round.temp.rfRound1Messages[i] = r1msg
+	round.out <- r1msg
+	return nil
+}
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// proof checks are in round 2
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// checkCommittee ensures that the parties are exactly the holders of the key shares in `ks`, or a strict subset of
+// them when `removal` is set.
+func checkCommittee(ks []*big.Int, parties tss.SortedPartyIDs, removal bool) error {
+	if removal && len(ks) <= len(parties) {
+		return fmt.Errorf("a removal needs fewer than the %d parties of the key but got %d", len(ks), len(parties))
+	}
+	if !removal && len(ks) != len(parties) {
+		return fmt.Errorf("refresh needs every one of the %d parties of the key but got %d", len(ks), len(parties))
+	}
+	keys := make(map[string]struct{}, len(ks))
+	for _, kj := range ks {
+		if kj == nil {
+			return errors.New("the save data has a missing share ID in Ks")
+		}
+		keys[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	for _, Pj := range parties {
+		if _, ok := keys[hex.EncodeToString(Pj.Key)]; !ok {
+			return fmt.Errorf("party %s does not hold a share of this key", Pj)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1-3. de-commit the poly*G of each Pj and verify our share against it
+	vjs := make([]vss.Vs, len(Ps))
+	vjs[PIdx] = round.temp.vs
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		if j == PIdx {
+			continue
+		}
+		KGCj := round.temp.KGCs[j]
+		r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RefreshRound2Message2)
+		cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: r2msg2.UnmarshalDeCommitment()}
+		ok, flatPolyGs := cmtDeCmt.DeCommit()
+		if !ok || len(flatPolyGs) != len(round.temp.vs)*2 { // they're points so * 2
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, errors.New("de-commitment verify failed"))
+			continue
+		}
+		PjVs, err := crypto.UnFlattenECPoints(tss.EC("eddsa"), flatPolyGs)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+		PjShare := vss.Share{
+			Threshold: round.degree(),
+			ID:        round.PartyID().KeyInt(),
+			Share:     r2msg1.UnmarshalShare(),
+		}
+		if round.redeal() {
+			ok = PjShare.Verify("eddsa", round.degree(), PjVs)
+		} else {
+			ok = PjShare.VerifyZeroShare("eddsa", round.degree(), PjVs)
+		}
+		if !ok {
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, errors.New("vss verify failed"))
+			continue
+		}
+		// a re-dealt polynomial must have lambda_j*Xj as its free term, or the key would change
+		if round.redeal() && !PjVs[0].Equals(round.temp.bigWs[j]) {
+			culprits = append(culprits, Pj)
+			multiErr = multierror.Append(multiErr, errors.New("the re-dealt polynomial does not share lambda_j*Xj"))
+			continue
+		}
+		vjs[j] = PjVs
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	// 4. new xi = xi + sum of the shares of zero, or the sum of the re-dealt shares
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	xi := new(big.Int).Set(round.input.Xi)
+	if round.redeal() {
+		xi = big.NewInt(0)
+	}
+	for j := range Ps {
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+
+	// 5. Vc = sum of the Vs, then new Xj = Xj + (v1*kj + .. + vt*kj^t), or v0 + (v1*kj + .. + vt*kj^t) when re-dealt
+	var err error
+	Vc := make(vss.Vs, len(round.temp.vs))
+	for c := range Vc {
+		Vc[c] = vjs[0][c]
+		for j := 1; j < len(Ps); j++ {
+			if Vc[c], err = Vc[c].Add(vjs[j][c]); err != nil {
+				return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), Ps[j])
+			}
+		}
+	}
+	if round.redeal() && !Vc[0].Equals(round.input.EDDSAPub) {
+		return round.WrapError(errors.New("assertion failed: the re-dealt key != EDDSAPub"), round.PartyID())
+	}
+	bigXj := make([]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		Xj, Vd := round.input.BigXj[j], Vc
+		if round.redeal() {
+			Xj, Vd = Vc[0], Vc[1:]
+		}
+		delta, err := Vd.EvaluateZero("eddsa", Pj.KeyInt())
+		if err == nil {
+			bigXj[j], err = Xj.Add(delta)
+		}
+		if err != nil {
+			return round.WrapError(errors.New("the refreshed BigXj is not on the curve"), Pj)
+		}
+	}
+	if !crypto.ScalarBaseMult(tss.EC("eddsa"), xi).Equals(bigXj[PIdx]) {
+		return round.WrapError(errors.New("assertion failed: the refreshed xi*G != Xi"), round.PartyID())
+	}
+
+	// 6. for this P: SAVE the refreshed data
+	round.save.LocalSecrets = keygen.LocalSecrets{Xi: xi, ShareID: round.input.ShareID}
+	round.save.EDDSAPub = round.input.EDDSAPub
+	round.save.ChainCode = round.input.ChainCode
+	for j := range Ps {
+		round.save.Ks[j] = round.input.Ks[j]
+		round.save.BigXj[j] = bigXj[j]
+	}
+
+	round.end <- *round.save
+
+	// security: the old share is no longer needed
+	round.input.Xi.SetInt64(0)
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sisu-network/tss-lib/common"
 	"github.com/sisu-network/tss-lib/crypto"
 	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/eddsa/recovery"
 	. "github.com/sisu-network/tss-lib/eddsa/resharing"
 	"github.com/sisu-network/tss-lib/eddsa/signing"
 	"github.com/sisu-network/tss-lib/test"
@@ -46,6 +47,12 @@ func TestE2EConcurrent(t *testing.T) {
 	firstPartyIdx, extraParties := 0, 1 // // extra can be 0 to N-first
 	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
 	assert.NoError(t, err, "should load keygen fixtures")
+	// the chain code agreed at keygen must be handed to the new committee
+	chainCode := make([]byte, keygen.ChainCodeLength)
+	chainCode[0] = 0x2a
+	for j := range oldKeys {
+		oldKeys[j].ChainCode = chainCode
+	}
 
 	// PHASE: resharing
 	oldP2PCtx := tss.NewPeerContext(oldPIDs)
@@ -128,6 +135,7 @@ func TestE2EConcurrent(t *testing.T) {
 			if save.Xi != nil {
 				index, err := save.OriginalIndex()
 				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				assert.Equal(t, chainCode, save.ChainCode, "the chain code must be kept")
 				newKeys[index] = save
 			} else {
 				endedOldCommittee++
@@ -227,3 +235,80 @@ signing:
 		}
 	}
 }
+
+func TestE2EAddParty(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures; every old party keeps its place and one party is added
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	pub := oldKeys[0].EDDSAPub
+
+	// the new committee shares the PartyIDs of the old one, so their Index is now the one in the new committee
+	added := tss.GenerateTestPartyIDs(1, len(oldPIDs))[0]
+	newPIDs := tss.SortPartyIDs(append(append(tss.UnSortedPartyIDs{}, oldPIDs...), added))
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+
+	errCh := make(chan *tss.Error, len(newPIDs))
+	outCh := make(chan tss.Message, len(newPIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(newPIDs))
+
+	parties := make([]*LocalParty, 0, len(newPIDs))
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, len(oldPIDs), threshold, len(newPIDs), threshold)
+		key := keygen.NewLocalPartySaveData(len(newPIDs))
+		if j := oldPIDs.IndexOf(pID.KeyInt()); 0 <= j {
+			assert.True(t, params.IsOldAndNewCommittee())
+			key = oldKeys[j]
+		}
+		parties = append(parties, NewLocalParty(params, key, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, 0, len(newPIDs))
+	for len(newKeys) < len(newPIDs) {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			// route by key: a party in both committees is listed once in each
+			delivered := make(map[string]bool, len(msg.GetTo()))
+			for _, destP := range msg.GetTo() {
+				key := destP.KeyInt().String()
+				if delivered[key] || key == msg.GetFrom().KeyInt().String() {
+					continue
+				}
+				delivered[key] = true
+				go test.SharedPartyUpdater(parties[newPIDs.IndexOf(destP.KeyInt())], msg, errCh)
+			}
+
+		case save := <-endCh:
+			newKeys = append(newKeys, save)
+		}
+	}
+
+	for _, key := range newKeys {
+		assert.NoError(t, key.Validate())
+		assert.True(t, key.EDDSAPub.Equals(pub), "the public key must be kept")
+		th, err := key.PublicData().Threshold()
+		assert.NoError(t, err)
+		assert.Equal(t, threshold, th)
+	}
+	sk, err := recovery.ReconstructKey(threshold, newKeys[1:threshold+2])
+	if assert.NoError(t, err, "any t+1 new shares must give back the key") {
+		pkX, _ := sk.Public()
+		assert.Equal(t, pub.X(), pkX)
+	}
+}
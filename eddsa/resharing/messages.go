@@ -13,6 +13,7 @@ import (
 	"github.com/sisu-network/tss-lib/crypto"
 	cmt "github.com/sisu-network/tss-lib/crypto/commitments"
 	"github.com/sisu-network/tss-lib/crypto/vss"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
 	"github.com/sisu-network/tss-lib/tss"
 )
 
@@ -35,6 +36,7 @@ func NewDGRound1Message(
 	to []*tss.PartyID,
 	from *tss.PartyID,
 	eddsaPub *crypto.ECPoint,
+	chainCode []byte,
 	vct cmt.HashCommitment,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
@@ -46,6 +48,7 @@ func NewDGRound1Message(
 	content := &DGRound1Message{
 		EddsaPub:    eddsaPub.ToProtobufPoint(),
 		VCommitment: vct.Bytes(),
+		ChainCode:   chainCode,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -55,7 +58,8 @@ func (m *DGRound1Message) ValidateBasic() bool {
 	return m != nil &&
 		m.GetEddsaPub() != nil &&
 		m.GetEddsaPub().ValidateBasic() &&
-		common.NonEmptyBytes(m.VCommitment)
+		common.NonEmptyBytes(m.VCommitment) &&
+		(len(m.GetChainCode()) == 0 || len(m.GetChainCode()) == keygen.ChainCodeLength)
 }
 
 func (m *DGRound1Message) UnmarshalEDDSAPub() (*crypto.ECPoint, error) {
@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -36,10 +37,11 @@ func (round *round1) Start() *tss.Error {
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	if !round.ReSharingParams().IsNewCommittee() {
+		round.allOldOK()
+	}
 
-	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.OldPartyIndex()
 
 	// 1. PrepareForSigning() -> w_i
 	xi, ks := round.input.Xi, round.input.Ks
@@ -69,7 +71,7 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.EDDSAPub, vCmt.C)
+		round.input.EDDSAPub, round.input.ChainCode, vCmt.C)
 	round.temp.dgRound1Messages[i] = r1msg
 	round.out <- r1msg
 
@@ -111,6 +113,15 @@ func (round *round1) Update() (bool, *tss.Error) {
 			return false, round.WrapError(errors.New("eddsa pub key did not match what we received previously"), msg.GetFrom())
 		}
 		round.save.EDDSAPub = candidate
+
+		// save the chain code, which every member of the old committee must agree on
+		chainCode := msg.Content().(*DGRound1Message).GetChainCode()
+		if !bytes.Equal(chainCode, r1msg.GetChainCode()) {
+			return false, round.WrapError(errors.New("chain code did not match what we received previously"), msg.GetFrom())
+		}
+		if len(chainCode) > 0 {
+			round.save.ChainCode = chainCode
+		}
 	}
 	return true, nil
 }
@@ -34,7 +34,7 @@ func (round *round4) Start() *tss.Error {
 	}
 
 	Pi := round.PartyID()
-	i := Pi.Index
+	i := round.NewPartyIndex()
 
 	// 1.
 	newXi := big.NewInt(0)
@@ -76,7 +76,7 @@ func (round *round4) Start() *tss.Error {
 			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
 		}
 
-		newXi = new(big.Int).Add(newXi, sharej.Share)
+		newXi = modQ.Add(newXi, sharej.Share)
 	}
 
 	// 9-12.
@@ -125,7 +125,7 @@ func (round *round4) Start() *tss.Error {
 	round.temp.newBigXjs = newBigXjs
 
 	// 21. Send an "ACK" message to both committees to signal that we're ready to save our data
-	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
+	r4msg := NewDGRound4Message(tss.SortedPartyIDs(round.OldAndNewParties()).Exclude(Pi), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
 	round.out <- r4msg
 
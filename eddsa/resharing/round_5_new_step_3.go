@@ -22,15 +22,15 @@ func (round *round5) Start() *tss.Error {
 	round.allOldOK()
 	round.allNewOK()
 
+	if round.IsOldCommittee() {
+		round.input.Xi.SetInt64(0)
+	}
 	if round.IsNewCommittee() {
 		// for this P: SAVE data
 		round.save.BigXj = round.temp.newBigXjs
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
-
-	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
 	}
 
 	round.end <- *round.save
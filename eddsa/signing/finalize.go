@@ -12,9 +12,9 @@ import (
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 
 	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
 	"github.com/sisu-network/tss-lib/tss"
 )
 
@@ -26,6 +26,21 @@ func (round *finalization) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	// identify the parties whose s_j does not satisfy s_j*G = Rj + c*lambda_j*Xj
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		if !round.verifyS(j, r3msg.UnmarshalS()) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("s_j verification failed"), culprits...)
+	}
+
 	sumS := round.temp.si
 	for j := range round.Parties().IDs() {
 		round.ok[j] = true
@@ -45,18 +60,11 @@ func (round *finalization) Start() *tss.Error {
 	signature.Signature = append(bigIntToEncodedBytes(round.temp.r)[:], sumS[:]...)
 	signature.R = round.temp.r.Bytes()
 	signature.S = s.Bytes()
-	signature.M = round.temp.m.Bytes()
+	signature.M = round.temp.m
 	round.data.Signature = signature
 
-	pk := edwards.PublicKey{
-		Curve: tss.EC("eddsa"),
-		X:     round.key.EDDSAPub.X(),
-		Y:     round.key.EDDSAPub.Y(),
-	}
-
-	ok := edwards.Verify(&pk, round.temp.m.Bytes(), round.temp.r, s)
-	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+	if err := round.data.VerifyWithOptions(round.key.EDDSAPub, round.temp.m, round.temp.opts); err != nil {
+		return round.WrapError(fmt.Errorf("signature verification failed: %v", err))
 	}
 	round.end <- round.data
 
@@ -76,3 +84,20 @@ func (round *finalization) Update() (bool, *tss.Error) {
 func (round *finalization) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// verifyS checks the share sj of the signature sent by Pj against the Rj that Pj de-committed in round 3
+func (round *finalization) verifyS(j int, sj *big.Int) bool {
+	ec := tss.EC("eddsa")
+	if sj.Cmp(ec.Params().N) >= 0 {
+		return false
+	}
+	Rj, Wj := round.temp.pointRjs[j], round.temp.bigWs[j]
+	if Rj == nil || Wj == nil {
+		return false
+	}
+	expected, err := Rj.Add(Wj.ScalarMult(round.temp.c))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(ec, sj).Equals(expected)
+}
@@ -48,8 +48,10 @@ type (
 
 		// temp data (thrown away after sign) / round 1
 		wi,
-		m,
 		ri *big.Int
+		m        []byte // the message as it is signed, without hashing
+		opts     *Options
+		dom2     []byte // the RFC 8032 prefix of the challenge hash, nil for Ed25519
 		pointRi  *crypto.ECPoint
 		deCommit cmt.HashDeCommitment
 
@@ -59,6 +61,10 @@ type (
 
 		// round 3
 		r *big.Int
+		// for identifying the parties that sent a bad s_j in finalization: Rj, lambda_j*Xj and the challenge c
+		pointRjs,
+		bigWs []*crypto.ECPoint
+		c *big.Int
 	}
 )
 
@@ -85,11 +91,61 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
-	p.temp.m = msg
+	p.temp.m = msg.Bytes()
 	p.temp.cjs = make([]*big.Int, partyCount)
+	p.temp.pointRjs = make([]*crypto.ECPoint, partyCount)
 	return p
 }
 
+// NewLocalPartyFromBytes constructs an EdDSA signing party for the raw message bytes `msg`. As RFC 8032 requires, the
+// message is hashed by the signature scheme itself, and leading zero bytes are kept. Prefer it to NewLocalParty, which
+// takes the message as an integer and so drops its leading zero bytes.
+//
+// The optional `optionalOpts` selects Ed25519ctx or Ed25519ph as ed25519.Options does: a Context alone selects
+// Ed25519ctx, and a Hash of crypto.SHA512 selects Ed25519ph, for which `msg` must be the SHA-512 digest of the message.
+// Verify such signatures with SignatureData.VerifyWithOptions, or with ed25519.VerifyWithOptions on Go 1.20 and later.
+func NewLocalPartyFromBytes(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+	optionalOpts ...*Options,
+) tss.Party {
+	if 1 < len(optionalOpts) {
+		panic(errors.New("NewLocalPartyFromBytes: expected 0 or 1 item in `optionalOpts`"))
+	}
+	p := NewLocalParty(new(big.Int), params, key, out, end).(*LocalParty)
+	p.temp.m = append([]byte(nil), msg...)
+	if len(optionalOpts) > 0 {
+		prefix, err := dom2(msg, optionalOpts[0])
+		if err != nil {
+			panic(fmt.Errorf("signing.NewLocalPartyFromBytes: %v", err))
+		}
+		p.temp.opts, p.temp.dom2 = optionalOpts[0], prefix
+	}
+	return p
+}
+
+// NewLocalPartyAtPath is NewLocalPartyFromBytes with the child key at the non-hardened derivation `path` of `key`, as
+// derived by keygen.LocalPartySaveData.DeriveChild. The signature verifies against the child public key returned by
+// keygen.DerivePublicKey.
+func NewLocalPartyAtPath(
+	msg []byte,
+	path []uint32,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+	optionalOpts ...*Options,
+) tss.Party {
+	child, err := key.DeriveChild(path)
+	if err != nil {
+		panic(fmt.Errorf("signing.NewLocalPartyAtPath: %v", err))
+	}
+	return NewLocalPartyFromBytes(msg, params, child, out, end, optionalOpts...)
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
 	return newRound1(p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
 }
@@ -7,6 +7,9 @@
 package signing
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
 	"fmt"
 	"math/big"
 	"sync/atomic"
@@ -141,3 +144,197 @@ signing:
 		}
 	}
 }
+
+func TestE2EFromBytes(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the leading zero bytes are part of the message and must be signed
+	msg := []byte{0x00, 0x00, 0x2a, 0x01}
+	sigs, _ := runSigning(t, signPIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyFromBytes(msg, params, keys[i], out, end)
+	}, nil)
+	pub := common.EncodeEdwardsPoint(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	for _, data := range sigs {
+		assert.Equal(t, msg, data.Signature.M, "M must be the message byte for byte")
+
+		// RFC 8032 verification of the raw message
+		assert.True(t, ed25519.Verify(pub, msg, data.Signature.Signature), "ed25519 verify must pass")
+		assert.NoError(t, data.VerifyBytes(keys[0].EDDSAPub, msg))
+	}
+}
+
+func TestE2EOptions(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msg := []byte("a message for every RFC 8032 scheme")
+	digest := sha512.Sum512(msg)
+	cases := []struct {
+		name string
+		msg  []byte
+		opts *Options
+	}{
+		{"Ed25519", msg, &Options{}},
+		{"Ed25519ctx", msg, &Options{Context: "tss-lib"}},
+		{"Ed25519ph", digest[:], &Options{Hash: crypto.SHA512}},
+		{"Ed25519ph with context", digest[:], &Options{Hash: crypto.SHA512, Context: "tss-lib"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sigs, _ := runSigning(t, signPIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+				return NewLocalPartyFromBytes(tc.msg, params, keys[i], out, end, tc.opts)
+			}, nil)
+			for _, data := range sigs {
+				assert.NoError(t, data.VerifyWithOptions(keys[0].EDDSAPub, tc.msg, tc.opts))
+				// the schemes are domain separated, so the signature of one must not verify as another
+				for _, other := range cases {
+					if other.opts.Hash == tc.opts.Hash && other.opts.Context == tc.opts.Context {
+						continue
+					}
+					assert.Error(t, data.VerifyWithOptions(keys[0].EDDSAPub, other.msg, other.opts),
+						"the signature must not verify as %s", other.name)
+				}
+			}
+		})
+	}
+
+	params := tss.NewParameters(tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	assert.Panics(t, func() {
+		NewLocalPartyFromBytes(msg, params, keys[0], nil, nil, &Options{Hash: crypto.SHA512})
+	}, "Ed25519ph must be given a SHA-512 digest")
+	assert.Panics(t, func() {
+		NewLocalPartyFromBytes(msg, params, keys[0], nil, nil, &Options{Hash: crypto.SHA256})
+	}, "only SHA-512 prehashing is defined")
+}
+
+func TestE2EAtPath(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	chainCode := make([]byte, keygen.ChainCodeLength)
+	chainCode[0] = 0x2a
+	for i := range keys {
+		keys[i].ChainCode = chainCode
+	}
+	path := []uint32{44, 501, 7}
+	childPub, _, err := keygen.DerivePublicKey(keys[0].EDDSAPub, chainCode, path)
+	assert.NoError(t, err)
+
+	msg := []byte("a message for a child key")
+	sigs, _ := runSigning(t, signPIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyAtPath(msg, path, params, keys[i], out, end)
+	}, nil)
+	pub := common.EncodeEdwardsPoint(childPub.X(), childPub.Y())
+	for _, data := range sigs {
+		assert.True(t, ed25519.Verify(pub, msg, data.Signature.Signature), "ed25519 verify must pass under the child key")
+		assert.Error(t, data.VerifyBytes(keys[0].EDDSAPub, msg), "the signature must not verify under the parent key")
+	}
+}
+
+func TestE2EImportedSeed(t *testing.T) {
+	setUp("info")
+
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 0x2a
+	allPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys, vs, err := keygen.ImportSeed(seed, testThreshold, allPIDs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, key := range keys {
+		assert.NoError(t, keygen.VerifyImportedShare(key, testThreshold, vs))
+	}
+
+	// sign with the last t+1 parties
+	signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs(allPIDs[testParticipants-testThreshold-1:]))
+	keys = keys[testParticipants-testThreshold-1:]
+
+	msg := []byte("a message signed by an imported key")
+	sigs, _ := runSigning(t, signPIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyFromBytes(msg, params, keys[i], out, end)
+	}, nil)
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	for _, data := range sigs {
+		assert.True(t, ed25519.Verify(pub, msg, data.Signature.Signature), "ed25519 verify must pass under the seed's key")
+	}
+}
+
+func TestIdentifiableAbort(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// party 0 sends a wrong s_i; it finishes, and every other party blames it
+	sigs, errs := runSigning(t, signPIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyFromBytes([]byte("abort"), params, keys[i], out, end)
+	}, func(m tss.Message) tss.Message {
+		if r3msg, ok := m.(tss.ParsedMessage).Content().(*SignRound3Message); ok && m.GetFrom().Index == 0 {
+			sI := new(big.Int).Add(r3msg.UnmarshalS(), big.NewInt(1))
+			return NewSignRound3Message(m.GetFrom(), sI)
+		}
+		return m
+	})
+	for _, err := range errs {
+		assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+	}
+	assert.Len(t, errs, len(signPIDs)-1)
+	assert.Len(t, sigs, 1)
+}
+
+// ----- //
+
+// runSigning starts the party that `newParty` builds for each of `signPIDs`, with the key at its index, and routes
+// their messages until every party has sent its signature data or failed. Each message is passed through `tamper`
+// first when it is set; without it, every party must succeed.
+func runSigning(
+	t *testing.T,
+	signPIDs tss.SortedPartyIDs,
+	newParty func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party,
+	tamper func(tss.Message) tss.Message,
+) (sigs []*SignatureData, errs []*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, newParty(i, params, outCh, endCh))
+	}
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	for len(sigs)+len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			if tamper == nil {
+				assert.FailNow(t, err.Error())
+			}
+			errs = append(errs, err)
+		case m := <-outCh:
+			if tamper != nil {
+				m = tamper(m)
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == m.GetFrom().Index || (m.GetTo() != nil && m.GetTo()[0].Index != P.PartyID().Index) {
+					continue
+				}
+				go test.SharedPartyUpdater(P, m, errCh)
+			}
+		case data := <-endCh:
+			sigs = append(sigs, data)
+		}
+	}
+	return
+}
@@ -11,12 +11,12 @@ import (
 	"math/big"
 
 	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
 	"github.com/sisu-network/tss-lib/tss"
 )
 
 // PrepareForSigning(), Fig. 7
 func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
-	modQ := common.ModInt(tss.EC("eddsa").Params().N)
 	if len(ks) != pax {
 		panic(fmt.Errorf("PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
 	}
@@ -25,20 +25,47 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
 	}
 
 	// 1-4.
-	wi = xi
-	for j := 0; j < pax; j++ {
-		if j == i {
+	lambda, err := lagrangeCoefficient(i, ks)
+	if err != nil {
+		panic(err)
+	}
+	wi = common.ModInt(tss.EC("eddsa").Params().N).Mul(xi, lambda)
+	return
+}
+
+// PrepareBigWs returns lambda_j*Xj for each signer Pj, the public counterpart of the wi returned by PrepareForSigning.
+func PrepareBigWs(ks []*big.Int, bigXs []*crypto.ECPoint) ([]*crypto.ECPoint, error) {
+	if len(ks) != len(bigXs) {
+		return nil, fmt.Errorf("PrepareBigWs: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs))
+	}
+	bigWs := make([]*crypto.ECPoint, len(ks))
+	for j := range ks {
+		lambdaJ, err := lagrangeCoefficient(j, ks)
+		if err != nil {
+			return nil, err
+		}
+		if bigXs[j] == nil {
+			return nil, fmt.Errorf("PrepareBigWs: BigXj of party %d is missing", j)
+		}
+		bigWs[j] = bigXs[j].ScalarMult(lambdaJ)
+	}
+	return bigWs, nil
+}
+
+// lagrangeCoefficient returns lambda_j = prod kc / (kc - kj) over the other signers, the coefficient of the share of
+// signer j when the secret is interpolated at 0
+func lagrangeCoefficient(j int, ks []*big.Int) (*big.Int, error) {
+	modQ := common.ModInt(tss.EC("eddsa").Params().N)
+	lambda := big.NewInt(1)
+	for c := range ks {
+		if c == j {
 			continue
 		}
-		ksj := ks[j]
-		ksi := ks[i]
-		if ksj.Cmp(ksi) == 0 {
-			panic(fmt.Errorf("index of two parties are equal"))
+		if ks[c].Cmp(ks[j]) == 0 {
+			return nil, fmt.Errorf("index of two parties are equal")
 		}
 		// big.Int Div is calculated as: a/b = a * modInv(b,q)
-		coef := modQ.Mul(ks[j], modQ.Inverse(new(big.Int).Sub(ksj, ksi)))
-		wi = modQ.Mul(wi, coef)
+		lambda = modQ.Mul(lambda, modQ.Mul(ks[c], modQ.Inverse(new(big.Int).Sub(ks[c], ks[j]))))
 	}
-
-	return
+	return lambda, nil
 }
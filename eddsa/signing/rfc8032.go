@@ -0,0 +1,103 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/agl/ed25519/edwards25519"
+
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// dom2Prefix starts the dom2(F, C) string that RFC 8032 hashes before R in Ed25519ph and Ed25519ctx
+const dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+// Options selects the RFC 8032 variant of EdDSA. It has the fields of ed25519.Options, which needs a newer Go than
+// this module supports:
+//   - Hash 0 and an empty Context is Ed25519, where the message is signed as is
+//   - Hash 0 and a Context is Ed25519ctx, where the message is signed as is
+//   - Hash crypto.SHA512 is Ed25519ph, where the message is the SHA-512 digest of the message
+type Options struct {
+	// Hash is crypto.SHA512 for Ed25519ph, or zero for Ed25519 and Ed25519ctx
+	Hash crypto.Hash
+	// Context is the context string of Ed25519ctx and Ed25519ph, of at most 255 bytes
+	Context string
+}
+
+// HashFunc returns o.Hash, so that Options implements crypto.SignerOpts as ed25519.Options does
+func (o *Options) HashFunc() crypto.Hash {
+	return o.Hash
+}
+
+// dom2 returns dom2(F, C) for the scheme selected by `opts`, or nil for plain Ed25519
+func dom2(msg []byte, opts *Options) ([]byte, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	if len(opts.Context) > 255 {
+		return nil, fmt.Errorf("the context must be at most 255 bytes, got %d", len(opts.Context))
+	}
+	var flag byte
+	switch opts.Hash {
+	case crypto.SHA512:
+		if len(msg) != sha512.Size {
+			return nil, fmt.Errorf("Ed25519ph expects a %d byte SHA-512 digest, got %d bytes", sha512.Size, len(msg))
+		}
+		flag = 1
+	case crypto.Hash(0):
+		if opts.Context == "" {
+			return nil, nil
+		}
+	default:
+		return nil, errors.New("the hash must be crypto.SHA512 for Ed25519ph or 0 for Ed25519 and Ed25519ctx")
+	}
+	prefix := append([]byte(dom2Prefix), flag, byte(len(opts.Context)))
+	return append(prefix, opts.Context...), nil
+}
+
+// verifyWithDom2 is RFC 8032 verification of the 64 byte `sig` with the challenge hash prefixed by `prefix`:
+// [S]B = R + [k]A with k = SHA-512(prefix || R || A || M), and S reduced modulo the group order
+func verifyWithDom2(pub *[32]byte, prefix, msg, sig []byte) bool {
+	if len(sig) != 64 {
+		return false
+	}
+	var encR, encS [32]byte
+	copy(encR[:], sig[:32])
+	copy(encS[:], sig[32:])
+	if encodedBytesToBigInt(&encS).Cmp(tss.EC("eddsa").Params().N) >= 0 {
+		return false
+	}
+
+	var A edwards25519.ExtendedGroupElement
+	if !A.FromBytes(pub) {
+		return false
+	}
+	edwards25519.FeNeg(&A.X, &A.X)
+	edwards25519.FeNeg(&A.T, &A.T)
+
+	var kDigest [64]byte
+	h := sha512.New()
+	_, _ = h.Write(prefix)
+	_, _ = h.Write(encR[:])
+	_, _ = h.Write(pub[:])
+	_, _ = h.Write(msg)
+	h.Sum(kDigest[:0])
+	var k [32]byte
+	edwards25519.ScReduce(&k, &kDigest)
+
+	// R' = [S]B - [k]A
+	var R edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&R, &k, &A, &encS)
+	var checkR [32]byte
+	R.ToBytes(&checkR)
+	return subtle.ConstantTimeCompare(encR[:], checkR[:]) == 1
+}
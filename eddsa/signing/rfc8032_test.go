@@ -0,0 +1,133 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDom2 signs with a single key and the challenge hash of the parties, and checks each mode against the test
+// vectors of RFC 8032 and plain Ed25519 against crypto/ed25519
+func TestDom2(t *testing.T) {
+	seed := sha512.Sum512([]byte("dom2 test key"))
+	priv := ed25519.NewKeyFromSeed(seed[:32])
+	msg := []byte("dom2")
+	for _, opts := range []*Options{nil, {}} {
+		prefix, err := dom2(msg, opts)
+		assert.NoError(t, err)
+		assert.Nil(t, prefix, "plain Ed25519 has no dom2 prefix")
+		assert.Equal(t, ed25519.Sign(priv, msg), signWithDom2(seed[:32], prefix, msg),
+			"the signature must match crypto/ed25519")
+	}
+
+	for _, tc := range []struct {
+		name                      string
+		seed, pub, msg, signature string
+		opts                      *Options
+	}{
+		{ // RFC 8032 section 7.2, foo
+			"Ed25519ctx",
+			"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+			"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+			"f726936d19c800494e3fdaff20b276a8",
+			"55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a" +
+				"8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d",
+			&Options{Context: "foo"},
+		},
+		{ // RFC 8032 section 7.3, the SHA-512 digest of abc
+			"Ed25519ph",
+			"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+			"ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+			"616263",
+			"98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae41" +
+				"31f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406",
+			&Options{Hash: crypto.SHA512},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			seed, msg, expected := mustHex(tc.seed), mustHex(tc.msg), mustHex(tc.signature)
+			if tc.opts.Hash == crypto.SHA512 {
+				digest := sha512.Sum512(msg)
+				msg = digest[:]
+			}
+			prefix, err := dom2(msg, tc.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, signWithDom2(seed, prefix, msg), "the signature must match RFC 8032")
+
+			var pub [32]byte
+			copy(pub[:], mustHex(tc.pub))
+			assert.True(t, verifyWithDom2(&pub, prefix, msg, expected))
+			assert.False(t, verifyWithDom2(&pub, nil, msg, expected), "the signature must not verify as Ed25519")
+			tampered := append([]byte(nil), expected...)
+			tampered[40] ^= 1
+			assert.False(t, verifyWithDom2(&pub, prefix, msg, tampered))
+		})
+	}
+
+	_, err := dom2(msg, &Options{Hash: crypto.SHA512})
+	assert.Error(t, err, "Ed25519ph takes a digest")
+	_, err = dom2(msg, &Options{Context: string(make([]byte, 256))})
+	assert.Error(t, err, "the context is at most 255 bytes")
+	_, err = dom2(msg, &Options{Hash: crypto.SHA256})
+	assert.Error(t, err, "only SHA-512 is defined for Ed25519ph")
+}
+
+func mustHex(s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// signWithDom2 is RFC 8032 signing with the challenge hash prefixed by `prefix`
+func signWithDom2(seed, prefix, msg []byte) []byte {
+	h := sha512.Sum512(seed)
+	var a [32]byte
+	copy(a[:], h[:32])
+	a[0] &= 248
+	a[31] &= 127
+	a[31] |= 64
+	var A edwards25519.ExtendedGroupElement
+	edwards25519.GeScalarMultBase(&A, &a)
+	var encA [32]byte
+	A.ToBytes(&encA)
+
+	var rDigest [64]byte
+	rHash := sha512.New()
+	_, _ = rHash.Write(prefix)
+	_, _ = rHash.Write(h[32:])
+	_, _ = rHash.Write(msg)
+	rHash.Sum(rDigest[:0])
+	var r [32]byte
+	edwards25519.ScReduce(&r, &rDigest)
+	var R edwards25519.ExtendedGroupElement
+	edwards25519.GeScalarMultBase(&R, &r)
+	var encR [32]byte
+	R.ToBytes(&encR)
+
+	var kDigest [64]byte
+	kHash := sha512.New()
+	_, _ = kHash.Write(prefix)
+	_, _ = kHash.Write(encR[:])
+	_, _ = kHash.Write(encA[:])
+	_, _ = kHash.Write(msg)
+	kHash.Sum(kDigest[:0])
+	var k [32]byte
+	edwards25519.ScReduce(&k, &kDigest)
+
+	var s [32]byte
+	edwards25519.ScMulAdd(&s, &k, &a, &r)
+	return append(encR[:], s[:]...)
+}
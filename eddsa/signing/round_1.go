@@ -92,7 +92,12 @@ func (round *round1) prepare() error {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
 	wi := PrepareForSigning(i, len(ks), xi, ks)
+	bigWs, err := PrepareBigWs(ks, round.key.BigXj)
+	if err != nil {
+		return err
+	}
 
 	round.temp.wi = wi
+	round.temp.bigWs = bigWs
 	return nil
 }
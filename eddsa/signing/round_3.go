@@ -12,6 +12,7 @@ import (
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/pkg/errors"
 
+	"github.com/sisu-network/tss-lib/common"
 	"github.com/sisu-network/tss-lib/crypto"
 	"github.com/sisu-network/tss-lib/crypto/commitments"
 	"github.com/sisu-network/tss-lib/tss"
@@ -43,17 +44,17 @@ func (round *round3) Start() *tss.Error {
 		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
 		ok, coordinates := cmtDeCmt.DeCommit()
 		if !ok {
-			return round.WrapError(errors.New("de-commitment verify failed"))
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
 		}
 		if len(coordinates) != 2 {
-			return round.WrapError(errors.New("length of de-commitment should be 2"))
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
 		}
 
 		Rj, err := crypto.NewECPoint(tss.EC("eddsa"), coordinates[0], coordinates[1])
-		Rj = Rj.EightInvEight()
 		if err != nil {
 			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
 		}
+		Rj = Rj.EightInvEight()
 		proof, err := r2msg.UnmarshalZKProof()
 		if err != nil {
 			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
@@ -63,6 +64,8 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(errors.New("failed to prove Rj"), Pj)
 		}
 
+		round.temp.pointRjs[j] = Rj
+
 		extendedRj := ecPointToExtendedElement(Rj.X(), Rj.Y())
 		R = addExtendedElements(R, extendedRj)
 	}
@@ -70,14 +73,15 @@ func (round *round3) Start() *tss.Error {
 	// 7. compute lambda
 	var encodedR [32]byte
 	R.ToBytes(&encodedR)
-	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	encodedPubKey := common.EncodeEdwardsPoint(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
 
-	// h = hash512(k || A || M)
+	// h = hash512(dom2(F, C) || k || A || M), where dom2 is empty for Ed25519
 	h := sha512.New()
 	h.Reset()
+	_, _ = h.Write(round.temp.dom2)
 	_, _ = h.Write(encodedR[:])
-	_, _ = h.Write(encodedPubKey[:])
-	_, _ = h.Write(round.temp.m.Bytes())
+	_, _ = h.Write(encodedPubKey)
+	_, _ = h.Write(round.temp.m)
 
 	var lambda [64]byte
 	h.Sum(lambda[:0])
@@ -91,6 +95,7 @@ func (round *round3) Start() *tss.Error {
 	// 9. store r3 message pieces
 	round.temp.si = &localS
 	round.temp.r = encodedBytesToBigInt(&encodedR)
+	round.temp.c = encodedBytesToBigInt(&lambdaReduced)
 
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+)
+
+// Verify checks the signature of the message in M under the EDDSAPub point `pub`.
+func (d *SignatureData) Verify(pub *crypto.ECPoint) error {
+	if err := d.check(pub); err != nil {
+		return err
+	}
+	return d.Signature.Verify(pub)
+}
+
+// VerifyBytes checks the signature of the raw message `msg` under the EDDSAPub point `pub`, as RFC 8032 requires.
+// The encoding of the signature must be canonical; see common.ECSignature.VerifyBytes.
+func (d *SignatureData) VerifyBytes(pub *crypto.ECPoint, msg []byte) error {
+	if err := d.check(pub); err != nil {
+		return err
+	}
+	return d.Signature.VerifyBytes(pub, msg)
+}
+
+// VerifyWithOptions checks the signature of `msg` under `pub` with the RFC 8032 scheme selected by `opts`, as
+// ed25519.VerifyWithOptions does. A nil `opts` is plain Ed25519, as in VerifyBytes. For Ed25519ph, `msg` is the SHA-512
+// digest of the message.
+func (d *SignatureData) VerifyWithOptions(pub *crypto.ECPoint, msg []byte, opts *Options) error {
+	if err := d.check(pub); err != nil {
+		return err
+	}
+	prefix, err := dom2(msg, opts)
+	if err != nil {
+		return err
+	}
+	if prefix == nil {
+		return d.Signature.VerifyBytes(pub, msg)
+	}
+	if len(d.Signature.M) != 0 && !bytes.Equal(d.Signature.M, msg) {
+		return errors.New("the signature was made for a different message M")
+	}
+	if len(d.Signature.Signature) != 64 {
+		return errors.New("an EdDSA signature must be 64 bytes")
+	}
+	var encPub [32]byte
+	copy(encPub[:], common.EncodeEdwardsPoint(pub.X(), pub.Y()))
+	if !verifyWithDom2(&encPub, prefix, msg, d.Signature.Signature) {
+		return errors.New("EdDSA signature verification failed")
+	}
+	return nil
+}
+
+func (d *SignatureData) check(pub *crypto.ECPoint) error {
+	if d == nil || d.Signature == nil {
+		return errors.New("the signature data has no signature")
+	}
+	if pub == nil {
+		return errors.New("the public key is nil")
+	}
+	if _, ok := pub.Curve().(*edwards.TwistedEdwardsCurve); !ok {
+		return errors.New("an EdDSA signature must be verified with an ed25519 public key")
+	}
+	return nil
+}
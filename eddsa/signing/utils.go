@@ -68,22 +68,6 @@ func copyBytes(aB []byte) *[32]byte {
 	return s
 }
 
-func ecPointToEncodedBytes(x *big.Int, y *big.Int) *[32]byte {
-	s := bigIntToEncodedBytes(y)
-	xB := bigIntToEncodedBytes(x)
-	xFE := new(edwards25519.FieldElement)
-	edwards25519.FeFromBytes(xFE, xB)
-	isNegative := edwards25519.FeIsNegative(xFE) == 1
-
-	if isNegative {
-		s[31] |= (1 << 7)
-	} else {
-		s[31] &^= (1 << 7)
-	}
-
-	return s
-}
-
 func reverse(s *[32]byte) {
 	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
 		s[i], s[j] = s[j], s[i]
@@ -0,0 +1,38 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keygen is the distributed key generation of sr25519 keys. An sr25519 secret key is a scalar of the
+// edwards25519 group, like an EdDSA one, and its public key is the same point encoded in Ristretto255; so the DKG is
+// the one of eddsa/keygen, built on crypto/vss, and the save data is interchangeable with it.
+package keygen
+
+import (
+	"errors"
+
+	"github.com/sisu-network/tss-lib/crypto/ristretto"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// LocalPartySaveData is the save data of an sr25519 key. EDDSAPub holds the public key point; see PublicKey.
+type LocalPartySaveData = keygen.LocalPartySaveData
+
+// NewLocalParty returns a party of the sr25519 DKG
+func NewLocalParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- LocalPartySaveData,
+) tss.Party {
+	return keygen.NewLocalParty(params, out, end)
+}
+
+// PublicKey returns the 32 byte sr25519 public key of the save data, the Ristretto255 encoding of EDDSAPub
+func PublicKey(save LocalPartySaveData) ([]byte, error) {
+	if save.EDDSAPub == nil || !save.EDDSAPub.ValidateBasic() {
+		return nil, errors.New("the save data has no public key")
+	}
+	return ristretto.Encode(save.EDDSAPub), nil
+}
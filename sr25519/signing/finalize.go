@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/ristretto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	// identify the parties whose s_j does not satisfy s_j*G = Rj + k*lambda_j*Xj
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		if !round.verifyS(j, r3msg.UnmarshalS()) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("s_j verification failed"), culprits...)
+	}
+
+	modN := common.ModInt(tss.EC("eddsa").Params().N)
+	s := round.temp.si
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		s = modN.Add(s, r3msg.UnmarshalS())
+	}
+
+	// save the signature for final output
+	round.data.Signature = encodeSignature(round.temp.encR, s)
+	round.data.PublicKey = round.temp.encPub
+	round.data.Context = round.temp.context
+	round.data.M = round.temp.m
+
+	if err := round.data.Verify(round.temp.encPub); err != nil {
+		return round.WrapError(fmt.Errorf("signature verification failed: %v", err))
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// verifyS checks the share sj of the signature sent by Pj against the Rj that Pj de-committed in round 3
+func (round *finalization) verifyS(j int, sj *big.Int) bool {
+	ec := tss.EC("eddsa")
+	if sj.Cmp(ec.Params().N) >= 0 {
+		return false
+	}
+	Rj, Wj := round.temp.pointRjs[j], round.temp.bigWs[j]
+	if Rj == nil || Wj == nil {
+		return false
+	}
+	expected, err := Rj.Add(Wj.ScalarMult(round.temp.k))
+	if err != nil {
+		return false
+	}
+	return ristretto.Equal(crypto.ScalarBaseMult(ec, sj), expected)
+}
@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sisu-network/tss-lib/common"
+	eddsakeygen "github.com/sisu-network/tss-lib/eddsa/keygen"
+	"github.com/sisu-network/tss-lib/sr25519/keygen"
+	"github.com/sisu-network/tss-lib/test"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures; an sr25519 key is an EdDSA key encoded in Ristretto255
+	keys, signPIDs, err := eddsakeygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pub, err := keygen.PublicKey(keys[0])
+	assert.NoError(t, err)
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	msg := []byte{0x00, 0x01, 0x02, 0x03}
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalParty([]byte(SubstrateContext), msg, params, keys[i], outCh, endCh))
+	}
+	sigs := make([]*SignatureData, 0, len(signPIDs))
+	if !runParties(t, parties, outCh, errCh, func() {
+		for range signPIDs {
+			sigs = append(sigs, <-endCh)
+		}
+	}) {
+		return
+	}
+	for _, sig := range sigs {
+		assert.Equal(t, sigs[0].Signature, sig.Signature, "all parties must output the same signature")
+		assert.Equal(t, pub, sig.PublicKey)
+		assert.Equal(t, msg, sig.M)
+		assert.NoError(t, sig.Verify(pub))
+		assert.NoError(t, Verify(pub, []byte(SubstrateContext), msg, sig.Signature))
+		assert.Error(t, Verify(pub, []byte("other"), msg, sig.Signature))
+	}
+}
+
+func TestIdentifiableAbort(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := eddsakeygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// PHASE: run rounds 1 and 2 by hand, then party 0 sends a wrong s_i and is blamed by everyone else
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalParty([]byte(SubstrateContext), []byte("blame"), params, keys[i], outCh, endCh))
+		assert.Nil(t, parties[i].Start())
+	}
+	for round := 1; round <= 2; round++ {
+		msgs := make([]tss.Message, 0, len(signPIDs))
+		for range signPIDs {
+			msgs = append(msgs, <-outCh)
+		}
+		for _, msg := range msgs {
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				_, err := P.Update(msg.(tss.ParsedMessage))
+				assert.Nil(t, err)
+			}
+		}
+	}
+	r3msgs := make([]tss.Message, len(signPIDs))
+	for range signPIDs {
+		msg := <-outCh
+		r3msgs[msg.GetFrom().Index] = msg
+	}
+	si := r3msgs[0].(tss.ParsedMessage).Content().(*SignRound3Message).UnmarshalS()
+	r3msgs[0] = NewSignRound3Message(signPIDs[0], new(big.Int).Add(si, big.NewInt(1)))
+
+	for i, P := range parties[1:] {
+		var err *tss.Error
+		for j, msg := range r3msgs {
+			if i+1 == j {
+				continue
+			}
+			_, err = P.Update(msg.(tss.ParsedMessage))
+		}
+		if assert.NotNil(t, err, "party %d must blame party 0", i+1) {
+			assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+		}
+	}
+}
+
+func runParties(t *testing.T, parties []tss.Party, outCh chan tss.Message, errCh chan *tss.Error, collect func()) bool {
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+			return false
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		collect()
+		close(done)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return false
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-done:
+			return true
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/eddsa/keygen"
+	eddsasigning "github.com/sisu-network/tss-lib/eddsa/signing"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the sr25519 signing, the same as round 1 of eddsa/signing
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. select ri
+	ri := common.GetRandomPositiveInt(tss.EC("eddsa").Params().N)
+
+	// 2. make commitment
+	pointRi := crypto.ScalarBaseMult(tss.EC("eddsa"), ri)
+	cmt := commitments.NewHashCommitment(pointRi.X(), pointRi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	// 4. broadcast commitment
+	r1msg := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	if round.key.EDDSAPub == nil || !round.key.EDDSAPub.ValidateBasic() {
+		return errors.New("the save data has no public key")
+	}
+	bigWs, err := eddsasigning.PrepareBigWs(ks, round.key.BigXj)
+	if err != nil {
+		return err
+	}
+
+	round.temp.wi = eddsasigning.PrepareForSigning(i, len(ks), xi, ks)
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/sisu-network/tss-lib/common"
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/commitments"
+	"github.com/sisu-network/tss-lib/crypto/ristretto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1. init R
+	R := round.temp.pointRi
+
+	// 2-6. compute R
+	i := round.PartyID().Index
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+
+		Rj, err := crypto.NewECPoint(tss.EC("eddsa"), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof()
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		ok = proof.Verify("eddsa", Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+
+		round.temp.pointRjs[j] = Rj
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Rj)"), Pj)
+		}
+	}
+
+	// 7. compute the challenge k from the Merlin transcript of the context, the message, the public key and R
+	encPub := ristretto.Encode(round.key.EDDSAPub)
+	encR := ristretto.Encode(R)
+	k := challenge(signingTranscript(round.temp.context, round.temp.m), encPub, encR)
+
+	// 8. compute si = ri + k*wi
+	modN := common.ModInt(tss.EC("eddsa").Params().N)
+	si := modN.Add(round.temp.ri, modN.Mul(k, round.temp.wi))
+
+	// 9. store r3 message pieces
+	round.temp.si = si
+	round.temp.encPub = encPub
+	round.temp.encR = encR
+	round.temp.k = k
+
+	// 10. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sisu-network/tss-lib/crypto"
+	"github.com/sisu-network/tss-lib/crypto/merlin"
+	"github.com/sisu-network/tss-lib/crypto/ristretto"
+	"github.com/sisu-network/tss-lib/tss"
+)
+
+const (
+	// SignatureLength is the length of an sr25519 signature: R || s
+	SignatureLength = 64
+
+	// SubstrateContext is the signing context of Substrate chains such as Polkadot and Kusama
+	SubstrateContext = "substrate"
+
+	// schnorrkelMarker is set in the last byte of s to tell Schnorrkel signatures from older ones
+	schnorrkelMarker = 0x80
+)
+
+// signingTranscript is the transcript of Schnorrkel's signing_context(context).bytes(msg)
+func signingTranscript(context, msg []byte) *merlin.Transcript {
+	t := merlin.NewTranscript("SigningContext")
+	t.AppendMessage([]byte(""), context)
+	t.AppendMessage([]byte("sign-bytes"), msg)
+	return t
+}
+
+// challenge returns the Schnorrkel challenge k of the public key `encPub` and the nonce commitment `encR`
+func challenge(t *merlin.Transcript, encPub, encR []byte) *big.Int {
+	t.AppendMessage([]byte("proto-name"), []byte("Schnorr-sig"))
+	t.AppendMessage([]byte("sign:pk"), encPub)
+	t.AppendMessage([]byte("sign:R"), encR)
+	k := t.ExtractBytes([]byte("sign:c"), 64)
+	reverse(k)
+	return new(big.Int).Mod(new(big.Int).SetBytes(k), tss.EC("eddsa").Params().N)
+}
+
+// Verify checks the sr25519 signature `sig` of `msg` in `context` under the 32 byte public key `pub`, as Schnorrkel's
+// verify_simple does. The Schnorrkel marker must be set in `sig`.
+func Verify(pub, context, msg, sig []byte) error {
+	if len(sig) != SignatureLength {
+		return errors.New("an sr25519 signature must be 64 bytes")
+	}
+	if sig[SignatureLength-1]&schnorrkelMarker == 0 {
+		return errors.New("the signature is not marked as a Schnorrkel signature")
+	}
+	A, err := ristretto.Decode(pub)
+	if err != nil {
+		return err
+	}
+	R, err := ristretto.Decode(sig[:32])
+	if err != nil {
+		return err
+	}
+	encS := append([]byte(nil), sig[32:]...)
+	encS[31] &^= schnorrkelMarker
+	reverse(encS)
+	s := new(big.Int).SetBytes(encS)
+	ec := tss.EC("eddsa")
+	if s.Cmp(ec.Params().N) >= 0 {
+		return errors.New("s is not reduced modulo the group order")
+	}
+	k := challenge(signingTranscript(context, msg), pub, sig[:32])
+
+	// s*G == R + k*A
+	expected, err := R.Add(A.ScalarMult(k))
+	if err != nil {
+		return err
+	}
+	if !ristretto.Equal(crypto.ScalarBaseMult(ec, s), expected) {
+		return errors.New("sr25519 signature verification failed")
+	}
+	return nil
+}
+
+// encodeSignature returns R || s with s little endian and the Schnorrkel marker set
+func encodeSignature(encR []byte, s *big.Int) []byte {
+	encS := s.FillBytes(make([]byte, 32))
+	reverse(encS)
+	encS[31] |= schnorrkelMarker
+	return append(append([]byte(nil), encR...), encS...)
+}
+
+func reverse(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
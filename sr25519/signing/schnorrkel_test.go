@@ -0,0 +1,45 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the test vector of sr25519-crust (test/ds.cpp), signed by schnorrkel in the "substrate" context
+const (
+	vectorPub = "46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a"
+	vectorMsg = "this is a message"
+	vectorSig = "4e172314444b8f820bb54c22e95076f220ed25373e5c178234aa6c211d29271244b947e3ff3418ff6b45fd1df1140c8cbff69fc58ee6dc96df70936a2bb74b82"
+)
+
+func TestVerifyVector(t *testing.T) {
+	pub, _ := hex.DecodeString(vectorPub)
+	sig, _ := hex.DecodeString(vectorSig)
+	assert.NoError(t, Verify(pub, []byte(SubstrateContext), []byte(vectorMsg), sig))
+
+	// another context, message or key
+	assert.Error(t, Verify(pub, []byte("other"), []byte(vectorMsg), sig))
+	assert.Error(t, Verify(pub, []byte(SubstrateContext), []byte("this is another message"), sig))
+	otherPub := append([]byte(nil), pub...)
+	otherPub[0] ^= 0x02
+	assert.Error(t, Verify(otherPub, []byte(SubstrateContext), []byte(vectorMsg), sig))
+
+	// without the Schnorrkel marker
+	unmarked := append([]byte(nil), sig...)
+	unmarked[SignatureLength-1] &^= schnorrkelMarker
+	assert.Error(t, Verify(pub, []byte(SubstrateContext), []byte(vectorMsg), unmarked))
+
+	// a tampered s
+	tampered := append([]byte(nil), sig...)
+	tampered[32] ^= 0x01
+	assert.Error(t, Verify(pub, []byte(SubstrateContext), []byte(vectorMsg), tampered))
+	assert.Error(t, Verify(pub, []byte(SubstrateContext), []byte(vectorMsg), sig[:63]))
+}
@@ -0,0 +1,30 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+)
+
+// SignatureData is the output of the sr25519 signing
+type SignatureData struct {
+	// Signature is the 64 byte Schnorrkel signature R || s, as accepted by `sr25519_verify` of Substrate
+	Signature []byte
+	// PublicKey is the 32 byte sr25519 public key that the signature verifies under
+	PublicKey []byte
+	// Context and M are the signing context and the message that were signed
+	Context,
+	M []byte
+}
+
+// Verify checks the signature of M in Context under the 32 byte sr25519 public key `pub`
+func (d *SignatureData) Verify(pub []byte) error {
+	if d == nil || d.Signature == nil {
+		return errors.New("the signature data has no signature")
+	}
+	return Verify(pub, d.Context, d.M, d.Signature)
+}
@@ -24,17 +24,19 @@ import (
 )
 
 const (
-	EcdsaScheme = "ecdsa"
-	EddsaScheme = "eddsa"
+	EcdsaScheme     = "ecdsa"
+	EcdsaP256Scheme = "ecdsa-p256"
+	EddsaScheme     = "eddsa"
 )
 
 var (
-	ed, ec elliptic.Curve
+	ed, ec, p256 elliptic.Curve
 )
 
 // Init default curve (secp256k1)
 func init() {
 	ec = s256k1.S256()
+	p256 = elliptic.P256()
 	ed = edwards.Edwards()
 }
 
@@ -43,6 +45,8 @@ func EC(scheme string) elliptic.Curve {
 	switch strings.ToLower(scheme) {
 	case "", EcdsaScheme:
 		return ec
+	case EcdsaP256Scheme:
+		return p256
 	case EddsaScheme:
 		return ed
 	default:
@@ -52,9 +56,11 @@ func EC(scheme string) elliptic.Curve {
 
 func GetCurveScheme(curve elliptic.Curve) string {
 	if curve == ec {
-		return "ecdsa"
+		return EcdsaScheme
+	} else if curve == p256 {
+		return EcdsaP256Scheme
 	} else if curve == ed {
-		return "eddsa"
+		return EddsaScheme
 	}
 
 	panic(fmt.Errorf("Unknown curve %v", curve))
@@ -14,6 +14,7 @@
 package tss
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"time"
 )
@@ -25,6 +26,7 @@ type (
 		partyCount          int
 		threshold           int
 		safePrimeGenTimeout time.Duration
+		curve               string
 	}
 
 	ReSharingParameters struct {
@@ -79,6 +81,27 @@ func (params *Parameters) SafePrimeGenTimeout() time.Duration {
 	return params.safePrimeGenTimeout
 }
 
+// SetCurve selects the curve used by the ECDSA protocols, e.g. EcdsaP256Scheme. The default is secp256k1.
+// The EdDSA protocols always use edwards25519 and ignore this setting.
+func (params *Parameters) SetCurve(scheme string) *Parameters {
+	EC(scheme) // panics on an unknown curve
+	params.curve = scheme
+	return params
+}
+
+// Curve returns the name of the curve used by the ECDSA protocols, as accepted by EC().
+func (params *Parameters) Curve() string {
+	if params.curve == "" {
+		return EcdsaScheme
+	}
+	return params.curve
+}
+
+// EC returns the curve used by the ECDSA protocols.
+func (params *Parameters) EC() elliptic.Curve {
+	return EC(params.Curve())
+}
+
 // ----- //
 
 // Exported, used in `tss` client
@@ -121,21 +144,26 @@ func (rgParams *ReSharingParameters) OldAndNewPartyCount() int {
 }
 
 func (rgParams *ReSharingParameters) IsOldCommittee() bool {
-	partyID := rgParams.partyID
-	for _, Pj := range rgParams.parties.IDs() {
-		if partyID.KeyInt().Cmp(Pj.KeyInt()) == 0 {
-			return true
-		}
-	}
-	return false
+	return 0 <= rgParams.OldPartyIndex()
 }
 
 func (rgParams *ReSharingParameters) IsNewCommittee() bool {
-	partyID := rgParams.partyID
-	for _, Pj := range rgParams.newParties.IDs() {
-		if partyID.KeyInt().Cmp(Pj.KeyInt()) == 0 {
-			return true
-		}
-	}
-	return false
+	return 0 <= rgParams.NewPartyIndex()
+}
+
+// IsOldAndNewCommittee returns true when this party is a member of both committees, e.g. a party that keeps its place
+// when other parties are added or removed. One LocalParty plays both roles with the same PartyID.
+func (rgParams *ReSharingParameters) IsOldAndNewCommittee() bool {
+	return rgParams.IsOldCommittee() && rgParams.IsNewCommittee()
+}
+
+// OldPartyIndex returns the index of this party in the old committee, or -1 if it is not a member.
+// A party in both committees usually has a different index in each, so use this rather than PartyID().Index.
+func (rgParams *ReSharingParameters) OldPartyIndex() int {
+	return rgParams.parties.IDs().IndexOf(rgParams.partyID.KeyInt())
+}
+
+// NewPartyIndex returns the index of this party in the new committee, or -1 if it is not a member.
+func (rgParams *ReSharingParameters) NewPartyIndex() int {
+	return rgParams.newParties.IDs().IndexOf(rgParams.partyID.KeyInt())
 }
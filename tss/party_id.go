@@ -129,6 +129,17 @@ func (spids SortedPartyIDs) FindByKey(key *big.Int) *PartyID {
 	return nil
 }
 
+// IndexOf returns the position of the party with `key` in the list, or -1 if it is not in the list.
+// Unlike PartyID.Index, it does not depend on the list the PartyID was last sorted in.
+func (spids SortedPartyIDs) IndexOf(key *big.Int) int {
+	for i, pid := range spids {
+		if pid.KeyInt().Cmp(key) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
 func (spids SortedPartyIDs) Exclude(exclude *PartyID) SortedPartyIDs {
 	newSpIDs := make(SortedPartyIDs, 0, len(spids))
 	for _, pid := range spids {